@@ -0,0 +1,125 @@
+package apperrors
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+)
+
+// the kind of error, sent to the client as extensions.code
+type Code string
+
+const (
+	CodeUnauthenticated Code = "UNAUTHENTICATED" // the user must sign in
+	CodeForbidden       Code = "FORBIDDEN"       // the user is signed in but not allowed
+	CodeNotFound        Code = "NOT_FOUND"       // the requested data does not exist
+	CodeValidation      Code = "VALIDATION"      // the request or its input is invalid
+	CodeRateLimited     Code = "RATE_LIMITED"    // too many attempts, try again later
+	CodeInternal        Code = "INTERNAL"        // unexpected failure, details are hidden from the client
+)
+
+// Error is an error whose message can be shared directly with the client
+type Error struct {
+	Code    Code
+	Message string
+	// the path of the invalid input, such as "input.password", for validation errors
+	Field string
+	// the correlation ID of the error_log row, for internal errors
+	CorrelationID string
+	// the underlying error, if any, which is never shown to the client
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return string(e.Code)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is lets errors.Is match any error with the same code against the sentinel errors below
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Code == e.Code
+}
+
+// sentinel errors to check the kind of an error with errors.Is
+var (
+	ErrUnauthenticated = &Error{Code: CodeUnauthenticated}
+	ErrForbidden       = &Error{Code: CodeForbidden}
+	ErrNotFound        = &Error{Code: CodeNotFound}
+	ErrValidation      = &Error{Code: CodeValidation}
+	ErrRateLimited     = &Error{Code: CodeRateLimited}
+	ErrInternal        = &Error{Code: CodeInternal}
+)
+
+func Unauthenticated(message string) *Error {
+	return &Error{Code: CodeUnauthenticated, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+func NotFound(message string) *Error {
+	return &Error{Code: CodeNotFound, Message: message}
+}
+
+// Validation creates an error for invalid input at the given field path
+// an empty field is used when the input as a whole is invalid
+func Validation(field string, message string) *Error {
+	return &Error{Code: CodeValidation, Message: message, Field: field}
+}
+
+func RateLimited(message string) *Error {
+	return &Error{Code: CodeRateLimited, Message: message}
+}
+
+// Internal creates the error shown to the client in place of an unexpected failure
+func Internal(message string, correlationID string, err error) *Error {
+	return &Error{Code: CodeInternal, Message: message, CorrelationID: correlationID, Err: err}
+}
+
+// Wrap gives an error a code and a client-facing message, keeping the original for errors.Is/As
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// WithField returns a copy of a validation error pointing at a different field path,
+// such as when a shared validator is used for a nested input
+// other errors are returned unchanged
+func WithField(err error, field string) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Code != CodeValidation {
+		return err
+	}
+	withField := *appErr
+	withField.Field = field
+	return &withField
+}
+
+// As finds the first typed error in the chain
+// sql.ErrNoRows is treated as a not found error, so missing rows need no special handling
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return Wrap(err, CodeNotFound, constants.NO_MATCHING_DATA_ERROR_MESSAGE), true
+	}
+	return nil, false
+}
+
+// CodeOf gets the code of an error, which is INTERNAL for untyped errors
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return CodeInternal
+}
@@ -0,0 +1,43 @@
+// reconcilevotes recomputes the upvote and downvote counters on posts and comments
+// from the vote tables and reports any that have drifted
+//
+// usage: go run ./cmd/reconcilevotes [-fix]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	database "github.com/jt-rose/clean_blog_server/database"
+	jobs "github.com/jt-rose/clean_blog_server/jobs"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "overwrite drifted counters with the recomputed values")
+	flag.Parse()
+
+	defer database.DB.Close()
+
+	drift, err := jobs.ReconcileVoteCounts(context.Background(), *fix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Vote count reconciliation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, row := range drift {
+		fmt.Printf("%s %d: stored %d up / %d down, actual %d up / %d down\n",
+			row.Table, row.ID, row.StoredUpvotes, row.StoredDownvotes, row.ActualUpvotes, row.ActualDownvotes)
+	}
+
+	switch {
+	case len(drift) == 0:
+		fmt.Println("All vote counters match the vote tables")
+	case *fix:
+		fmt.Printf("Fixed %d drifted vote counters\n", len(drift))
+	default:
+		fmt.Printf("Found %d drifted vote counters, run with -fix to correct them\n", len(drift))
+		os.Exit(1)
+	}
+}
@@ -15,6 +15,21 @@ type ENV_Variables struct {
 	SESSION_KEY   string
 	EMAIL_ADDRESS string
 	EMAIL_PASSWORD string
+	MAIL_TRANSPORT string // smtp, maildir, or memory
+	SMTP_HOST string
+	SMTP_PORT string
+	SMTP_TLS_MODE string // starttls, tls, or none
+	MAILDIR string // where the maildir transport saves emails
+	TWO_FACTOR_KEY string // encrypts TOTP secrets, must differ from the session key
+}
+
+// read an optional environment variable
+func getEnvOrDefault(key string, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
 }
 
 func loadEnvVariables() ENV_Variables {
@@ -31,12 +46,23 @@ func loadEnvVariables() ENV_Variables {
 		SESSION_KEY:      os.Getenv("SESSION_KEY"),
 		EMAIL_ADDRESS: os.Getenv("EMAIL_ADDRESS"),
 		EMAIL_PASSWORD: os.Getenv("EMAIL_PASSWORD"),
+		MAIL_TRANSPORT: getEnvOrDefault("MAIL_TRANSPORT", "smtp"),
+		SMTP_HOST: getEnvOrDefault("SMTP_HOST", "smtp.gmail.com"),
+		SMTP_PORT: getEnvOrDefault("SMTP_PORT", "587"),
+		SMTP_TLS_MODE: getEnvOrDefault("SMTP_TLS_MODE", "starttls"),
+		MAILDIR: getEnvOrDefault("MAILDIR", "maildir"),
+		TWO_FACTOR_KEY: os.Getenv("TWO_FACTOR_KEY"),
 	}
 
 	if ENV_VAR.DATABASE_URL == "" || ENV_VAR.DATABASE_PORT == "" || ENV_VAR.SERVER_PORT == "" || ENV_VAR.SESSION_KEY == "" {
 		log.Fatal("Error loading .env file")
 	}
 
+	// a leaked session key must not also decrypt every TOTP secret
+	if ENV_VAR.TWO_FACTOR_KEY == "" || ENV_VAR.TWO_FACTOR_KEY == ENV_VAR.SESSION_KEY {
+		log.Fatal("TWO_FACTOR_KEY must be set and differ from SESSION_KEY")
+	}
+
 	return ENV_VAR
 }
 
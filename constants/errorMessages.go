@@ -1,9 +1,11 @@
 package constants
 
 // list different types of custom error messages
+// these are returned to the client inside typed errors from the apperrors package
 var UNAUTHENTICATED_ERROR_MESSAGE = "Must be logged in!"
-var ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE = "Only the author of the blog may add, edit, or delete posts"
-var ONLY_COMMENT_AUTHOR_MAY_EDIT = "Only the author of a comment can edit, delete, or restore it"
+var ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE = "Only the author of a post or an admin may edit, delete, or restore it"
+var ONLY_COMMENT_AUTHOR_MAY_EDIT = "Only the author of a comment can edit it"
+var ONLY_COMMENT_AUTHOR_OR_MODERATOR_ALLOWED = "Only the author of a comment or a moderator can delete or restore it"
 var PASSWORD_TOO_SHORT_ERROR_MESSAGE = "Password must be 8 or more characters long"
 var PASSWORD_LACKS_MIX_OF_CHARS_ERROR_MESSAGE = "Password must have letters, numbers, and special characters"
 var PASSWORD_LACKS_UPPER_AND_LOWERCASE_LETTERS_ERROR_MESSAGE = "Password must have both lower and uppercase characters"
@@ -12,32 +14,37 @@ var USERNAME_INAPPROPRIATE_ERROR_MESSAGE = "Must use an appropriate username"
 var USERNAME_NOT_URL_COMPATIBLE_ERROR_MESSAGE = "Please use only letters, numbers, and hyphens for your username"
 var INVALID_EMAIL_ERROR_MESSAGE = "Must use a valid email address"
 var INVALID_USERNAME_PASSWORD_ERROR_MESSAGE = "Incorrect username / password combination!"
+var PUBLISHED_AND_SCHEDULED_ERROR_MESSAGE = "Posts scheduled with publish_at must set published to false"
+var TAG_TOO_LONG_ERROR_MESSAGE = "Tags must be 50 characters or less"
+var TOO_MANY_TAGS_ERROR_MESSAGE = "Posts may have up to 10 tags"
+var INVALID_CURSOR_ERROR_MESSAGE = "Invalid pagination cursor"
+var POST_NOT_FOUND_ERROR_MESSAGE = "Post not found"
+var COMMENT_NOT_FOUND_ERROR_MESSAGE = "Comment not found"
+var PERMISSION_DENIED_ERROR_MESSAGE = "You do not have permission to do this"
+var CANNOT_REVOKE_OWN_ADMIN_ERROR_MESSAGE = "Admins cannot revoke their own admin role"
+var FLAGGED_VOTE_NOT_FOUND_ERROR_MESSAGE = "Flagged vote not found"
+var INVALID_VERIFICATION_TOKEN_ERROR_MESSAGE = "This verification link is invalid or has expired"
+var EMAIL_ALREADY_VERIFIED_ERROR_MESSAGE = "Your email address has already been verified"
+var EMAIL_NOT_VERIFIED_ERROR_MESSAGE = "Please verify your email address first"
+var INVALID_RESET_TOKEN_ERROR_MESSAGE = "This password reset link is invalid or has expired"
+var TWO_FACTOR_ALREADY_ENABLED_ERROR_MESSAGE = "Two-factor authentication is already enabled"
+var TWO_FACTOR_NOT_ENABLED_ERROR_MESSAGE = "Two-factor authentication is not enabled"
+var TWO_FACTOR_ENROLLMENT_NOT_STARTED_ERROR_MESSAGE = "Start two-factor enrollment before confirming it"
+var INVALID_TWO_FACTOR_CODE_ERROR_MESSAGE = "Invalid authentication code"
+var TWO_FACTOR_CHALLENGE_EXPIRED_ERROR_MESSAGE = "Your sign in has expired, please enter your password again"
+var INCORRECT_PASSWORD_ERROR_MESSAGE = "Incorrect password"
+var INVALID_API_TOKEN_ERROR_MESSAGE = "Invalid, expired, or revoked API token"
+var API_TOKEN_NOT_ALLOWED_ERROR_MESSAGE = "API tokens cannot be used for this, please sign in"
+var API_TOKEN_MISSING_SCOPE_ERROR_MESSAGE = "This API token does not have the scope needed for this"
+var API_TOKEN_NOT_FOUND_ERROR_MESSAGE = "API token not found"
+var API_TOKEN_NAME_INVALID_ERROR_MESSAGE = "API token names must be 1 to 100 characters long"
+var API_TOKEN_SCOPES_REQUIRED_ERROR_MESSAGE = "API tokens need at least one scope"
+var API_TOKEN_EXPIRY_INVALID_ERROR_MESSAGE = "API tokens must expire at least one day after they are created"
+var REVISION_DIFF_TOO_LARGE_ERROR_MESSAGE = "These revisions are too different to compare"
+var RATE_LIMITED_ERROR_MESSAGE = "Too many requests, please try again later"
+var NO_MATCHING_DATA_ERROR_MESSAGE = "No matching data found in database"
 
-// confirm if error has custom error message
-// which can be shared directly with the client
-func IsCustomError(errMessage string) bool {
-	// store custom errors in array
-	errorMessages := [...]string{
-		UNAUTHENTICATED_ERROR_MESSAGE,
-		ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE,
-		ONLY_COMMENT_AUTHOR_MAY_EDIT,
-		PASSWORD_TOO_SHORT_ERROR_MESSAGE,
-		PASSWORD_LACKS_MIX_OF_CHARS_ERROR_MESSAGE,
-		PASSWORD_LACKS_UPPER_AND_LOWERCASE_LETTERS_ERROR_MESSAGE,
-		USERNAME_TOO_SHORT_ERROR_MESSAGE,
-		USERNAME_INAPPROPRIATE_ERROR_MESSAGE,
-		USERNAME_NOT_URL_COMPATIBLE_ERROR_MESSAGE,
-		INVALID_EMAIL_ERROR_MESSAGE,
-		INVALID_USERNAME_PASSWORD_ERROR_MESSAGE,
-	}
-
-	// loop through to find match
-	for _, value := range errorMessages {
-		if errMessage == value {
-			return true
-		}
-	}
-
-	// return false if no match found
-	return false
-}
\ No newline at end of file
+// generic messages shown in place of unexpected errors
+// the details are stored in the error log instead
+var INTERNAL_ERROR_MESSAGE = "data currently unavailable"
+var PANIC_ERROR_MESSAGE = "Internal server error!"
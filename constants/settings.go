@@ -0,0 +1,98 @@
+package constants
+
+import "time"
+
+// deleted posts and comments are kept in the trash for this long
+// before being permanently removed by the purge job
+var TRASH_RETENTION_DAYS = 30
+var TRASH_RETENTION_PERIOD = time.Hour * 24 * time.Duration(TRASH_RETENTION_DAYS)
+
+// how often the purge job checks for expired trash
+var TRASH_PURGE_INTERVAL = time.Hour
+
+// how often the publishing job checks for scheduled posts that are due
+var SCHEDULED_PUBLISH_INTERVAL = time.Minute
+
+// how long rendered markdown / html is cached in redis
+var RENDERED_HTML_CACHE_TTL = time.Hour * 24
+
+// revisions are only compared when they differ by at most this many lines,
+// since the memory used by a diff grows with the square of the number of changed lines
+var REVISION_DIFF_MAX_EDITS = 1000
+var REVISION_DIFF_MAX_LINES = 20000
+
+// the most comments returned by a single getCommentTree query
+var COMMENT_TREE_MAX_NODES = 500
+
+// how often the ranking job recomputes site-wide post scores
+var POST_RANKING_INTERVAL = time.Minute * 5
+
+// each comment counts as this many upvotes when ranking posts
+var RANKING_COMMENT_WEIGHT = 2
+
+// how quickly trending scores decay as a post ages, higher values favor newer posts
+var TRENDING_GRAVITY = 1.8
+
+// reputation given to the author of a post or comment for each vote it receives
+// a vote changed to neutral takes back what the previous vote gave
+var REPUTATION_POST_UPVOTE = 10
+var REPUTATION_POST_DOWNVOTE = -2
+var REPUTATION_COMMENT_UPVOTE = 5
+var REPUTATION_COMMENT_DOWNVOTE = -1
+
+// how often the vote analyzer checks recent votes for manipulation
+// and how far back it looks each time
+var VOTE_ANALYSIS_INTERVAL = time.Minute * 10
+var VOTE_ANALYSIS_LOOKBACK = time.Hour * 24 * 7
+
+// accounts younger than this are flagged when they cast this many votes within the burst window
+var VOTE_NEW_ACCOUNT_AGE = time.Hour * 72
+var VOTE_BURST_WINDOW = time.Minute * 10
+var VOTE_BURST_SIZE = 15
+
+// pairs of users are flagged as a voting ring when they share at least this many identical votes
+// and those votes make up at least this share of everything either of them voted on
+var VOTE_RING_MIN_SHARED = 10
+var VOTE_RING_MIN_AGREEMENT = 0.9
+
+// roles given to each newly registered user
+// the author role must be granted by an admin before a user can write posts
+var DEFAULT_USER_ROLES = []string{"reader"}
+
+// how long an email verification link stays valid
+// and how long users must wait before requesting another one
+var EMAIL_VERIFICATION_TTL = time.Hour * 24
+var EMAIL_VERIFICATION_RESEND_COOLDOWN = time.Minute
+
+// how long a password reset link stays valid
+var PASSWORD_RESET_TTL = time.Hour
+
+// max password reset requests for one account, and from one IP address, within each window
+var PASSWORD_RESET_LIMIT_WINDOW = time.Hour
+var PASSWORD_RESET_ACCOUNT_LIMIT = 3
+var PASSWORD_RESET_IP_LIMIT = 10
+
+// emails are sent in the background by a queue of this many workers
+// failed emails are retried with a delay that doubles after each attempt
+var MAIL_QUEUE_WORKERS = 2
+var MAIL_QUEUE_SIZE = 1000
+var MAIL_MAX_ATTEMPTS = 5
+var MAIL_RETRY_DELAY = time.Second * 30
+
+// how long the server waits for open requests to finish on shutdown, before delivering queued emails
+var SHUTDOWN_TIMEOUT = time.Second * 10
+
+// the name authenticator apps show for this site, and the number of recovery codes given to each user
+var TWO_FACTOR_ISSUER = "Clean Blog"
+var TWO_FACTOR_RECOVERY_CODES = 10
+
+// how long a session lasts, matching the default max age of the redis session store
+var SESSION_MAX_AGE = time.Hour * 24 * 30
+
+// how long users have to enter a two-factor code after their password,
+// and how many codes they may try in that time
+var TWO_FACTOR_CHALLENGE_TTL = time.Minute * 5
+var TWO_FACTOR_ATTEMPT_LIMIT = 5
+
+// how often a personal access token's last_used_at is updated while it is in use
+var API_TOKEN_LAST_USED_INTERVAL = time.Minute
@@ -9,9 +9,17 @@ import (
 	"github.com/jt-rose/clean_blog_server/graph/model"
 	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
 	utils "github.com/jt-rose/clean_blog_server/utils"
+	queries "github.com/volatiletech/sqlboiler/v4/queries"
 	qm "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
+// the loaders are generated by dataloaden, run go generate ./dataloader after changing a key or value type
+//go:generate go run github.com/vektah/dataloaden UserLoader int github.com/jt-rose/clean_blog_server/graph/model.User
+//go:generate go run github.com/vektah/dataloaden PaginatedCommentsLoader github.com/jt-rose/clean_blog_server/dataloader.CommentsKey github.com/jt-rose/clean_blog_server/graph/model.PaginatedComments
+//go:generate go run github.com/vektah/dataloaden VotesLoader int github.com/jt-rose/clean_blog_server/graph/model.Votes
+//go:generate go run github.com/vektah/dataloaden TagsLoader int []string
+//go:generate go run github.com/vektah/dataloaden VoteValueLoader github.com/jt-rose/clean_blog_server/dataloader.VoteKey github.com/jt-rose/clean_blog_server/graph/model.VoteValue
+
 const loadersKey = "dataloaders"
 
 type Loaders struct {
@@ -21,6 +29,29 @@ type Loaders struct {
 	CommentByCommentID PaginatedCommentsLoader // used for getting subcomments
 	VotesByPostID VotesLoader
 	VotesByCommentID VotesLoader
+	TagsByPostID TagsLoader
+	MyVoteByPostID VoteValueLoader
+	MyVoteByCommentID VoteValueLoader
+}
+
+// CommentsKey identifies the page of comments to load for a single parent
+// the parent is a post, comment, or user depending on the loader
+type CommentsKey struct {
+	ParentID int
+	Limit    int
+	Sort     model.CommentSort
+}
+
+// VoteKey identifies a single user's vote on a post or comment
+type VoteKey struct {
+	UserID int
+	ID     int
+}
+
+// a single tag name joined with the post it is applied to
+type postTag struct {
+	PostID  int    `boil:"post_id"`
+	TagName string `boil:"tag_name"`
 }
 
 func LoadUsers(ctx context.Context) func(ids []int) ([]model.User, []error){
@@ -60,196 +91,138 @@ func LoadUsers(ctx context.Context) func(ids []int) ([]model.User, []error){
 }
 }
 
-func LoadCommentsByCommentID(ctx context.Context) func(ids []int) ([]model.PaginatedComments, []error){
-	return func(ids []int) ([]model.PaginatedComments, []error) {
-		// format user ids as SQL string param
-		queryParam := utils.FormatSliceForSQLParams(ids)
-
-		// attempt to fetch subcomments for each comment id
-		comments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
-		if err != nil {
-			return nil, []error{err}
-		}
+// the maximum number of comments returned for a single parent
+// additional comments can be fetched with the getCommentsConnection resolver
+const maxCommentsPerParent = 20
 
-		// get the comment id for each comment found
-		var currentCommentIDList []int
-	for _, value := range comments {
-		currentCommentIDList = append(currentCommentIDList, value.CommentID)
+// NewCommentsKey builds a dataloader key, capping the limit
+func NewCommentsKey(parentID int, limit int, sort model.CommentSort) CommentsKey {
+	if limit > maxCommentsPerParent {
+		limit = maxCommentsPerParent
 	}
-
-	// format comment ids as SQL string param
-	queryParam = utils.FormatSliceForSQLParams(currentCommentIDList)
-	// check if subcomments exist that reference the current subcomment ids as a parent
-	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
-	if err != nil {
-		return nil, []error{err}
+	if limit < 0 {
+		limit = 0
 	}
+	return CommentsKey{ParentID: parentID, Limit: limit, Sort: sort}
+}
 
-	// format comments for graphQL response and note if they have subcomments
-	var formattedComments []model.Comment
-	for _, comment := range comments {
-		// check if comment has subcomments
-		hasSubComment := false
-		for _, subComment := range subComments {
-			if subComment.ResponseToCommentID.Int == comment.CommentID {
-				hasSubComment = true
-			}
-		}
-		fmtComment := utils.ConvertComment(comment, hasSubComment)
-		formattedComments = append(formattedComments, fmtComment)
+// loads the first page of comments for each key, partitioned by parentColumn
+// filter is applied to every comment and should include the deleted_at check
+func loadCommentPages(ctx context.Context, keys []CommentsKey, parentColumn string, filter string) ([]model.PaginatedComments, []error) {
+	// keys in the same batch usually share a limit and sort, but group them in case they do not
+	type pageOptions struct {
+		limit int
+		sort  model.CommentSort
 	}
-	
-	// sort formatted comments by post ids from field resolver args
-	// and format each association as a PaginatedComments response
-	var formattedPaginatedComments []model.PaginatedComments
-	for _, commentID := range ids {
-		var commentsByCommentID []*model.Comment
-		for _, comment := range formattedComments {
-			if *comment.ResponseToCommentID == commentID {
-				// preserve the current comments data before comment is updated
-				currentComment := *&comment
-				commentsByCommentID = append(commentsByCommentID, &currentComment)
-			}
-			
-		}
-
-		paginatedResponse := model.PaginatedComments{
-			Comments: commentsByCommentID,
-			More: false,
-		}
-		formattedPaginatedComments = append(formattedPaginatedComments, paginatedResponse)
+	parentIDsByOptions := make(map[pageOptions][]int)
+	for _, key := range keys {
+		options := pageOptions{limit: key.Limit, sort: key.Sort}
+		parentIDsByOptions[options] = append(parentIDsByOptions[options], key.ParentID)
 	}
 
-	return formattedPaginatedComments, nil
-}
-}
-
-func LoadCommentsByPostID(ctx context.Context) func(ids []int) ([]model.PaginatedComments, []error){
-	return func(ids []int) ([]model.PaginatedComments, []error) {
-		// format post ids as SQL string param
-		queryParam := utils.FormatSliceForSQLParams(ids)
-
-		// attempt to fetch all top-level comments on each post
-		comments, err := sql_models.Comments(qm.Where("post_id = ANY(?::int[]) AND response_to_comment_id IS NULL", queryParam)).All(ctx, database.DB)
+	// fetch the first limit + 1 comments for each parent, using the extra comment to check for more
+	commentsByKey := make(map[CommentsKey]sql_models.CommentSlice)
+	var allComments sql_models.CommentSlice
+	for options, parentIDs := range parentIDsByOptions {
+		// the same ordering is used in the ROW_NUMBER() window and the final query
+		// so that each parent's page is in the same order
+		ordering := utils.CommentOrderBy(options.sort, "comments")
+		queryParam := utils.FormatSliceForSQLParams(parentIDs)
+
+		comments, err := sql_models.Comments(
+			qm.Where(`comment_id IN (
+				SELECT ranked.comment_id FROM (
+					SELECT comment_id, ROW_NUMBER() OVER (PARTITION BY `+parentColumn+` ORDER BY `+ordering+`) AS row_number
+					FROM comments
+					WHERE `+parentColumn+` = ANY(?::int[]) AND `+filter+`
+				) ranked
+				WHERE ranked.row_number <= ?)`, queryParam, options.limit+1),
+			qm.OrderBy(ordering),
+		).All(ctx, database.DB)
 		if err != nil {
 			return nil, []error{err}
 		}
 
-		// get the comment id for each comment found
-		var currentCommentIDList []int
-	for _, value := range comments {
+		// group comments by parent, preserving the order from the query
+		for _, comment := range comments {
+			var parentID int
+			switch parentColumn {
+			case "post_id":
+				parentID = comment.PostID
+			case "response_to_comment_id":
+				parentID = comment.ResponseToCommentID.Int
+			case "user_id":
+				parentID = comment.UserID
+			}
+			key := CommentsKey{ParentID: parentID, Limit: options.limit, Sort: options.sort}
+			commentsByKey[key] = append(commentsByKey[key], comment)
+		}
+		allComments = append(allComments, comments...)
+	}
+
+	// get the comment id for each comment found
+	var currentCommentIDList []int
+	for _, value := range allComments {
 		currentCommentIDList = append(currentCommentIDList, value.CommentID)
 	}
 
 	// format comment ids as SQL string param
-	queryParam = utils.FormatSliceForSQLParams(currentCommentIDList)
+	queryParam := utils.FormatSliceForSQLParams(currentCommentIDList)
 	// check if subcomments exist that reference the current comment ids as a parent
-	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
+	subComments, err := sql_models.Comments(qm.Select("response_to_comment_id"), qm.Where("response_to_comment_id = ANY(?::int[]) AND deleted_at IS NULL", queryParam)).All(ctx, database.DB)
 	if err != nil {
 		return nil, []error{err}
 	}
+	hasSubComments := make(map[int]bool)
+	for _, subComment := range subComments {
+		hasSubComments[subComment.ResponseToCommentID.Int] = true
+	}
 
-	// format comments for graphQL response and note if they have subcomments
-	var formattedComments []model.Comment
-	for _, comment := range comments {
-		// check if comment has subcomments
-		hasSubComment := false
-		for _, subComment := range subComments {
-			if subComment.ResponseToCommentID.Int == comment.CommentID {
-				hasSubComment = true
-			}
+	// format each page as a PaginatedComments response according to the order of keys in dataloader arg
+	formattedPaginatedComments := make([]model.PaginatedComments, len(keys))
+	for i, key := range keys {
+		comments := commentsByKey[key]
+
+		// drop the extra comment used to check for more
+		more := len(comments) > key.Limit
+		if more {
+			comments = comments[:key.Limit]
 		}
-		fmtComment := utils.ConvertComment(comment, hasSubComment)
-		formattedComments = append(formattedComments, fmtComment)
-	}
-	
-	// sort formatted comments by post ids from field resolver args
-	// and format each association as a PaginatedComments response
-	var formattedPaginatedComments []model.PaginatedComments
-	for _, postID := range ids {
-		var commentsByPostID []*model.Comment
-		for _, comment := range formattedComments {
-			if comment.PostID == postID {
-				// preserve the current comments data before comment is updated
-				currentComment := *&comment
-				commentsByPostID = append(commentsByPostID, &currentComment)
-			}
-			
+
+		formattedComments := []*model.Comment{}
+		for _, comment := range comments {
+			fmtComment := utils.ConvertComment(comment, hasSubComments[comment.CommentID])
+			formattedComments = append(formattedComments, &fmtComment)
 		}
 
-		paginatedResponse := model.PaginatedComments{
-			Comments: commentsByPostID,
-			More: false,
+		formattedPaginatedComments[i] = model.PaginatedComments{
+			Comments: formattedComments,
+			More:     more,
 		}
-		formattedPaginatedComments = append(formattedPaginatedComments, paginatedResponse)
 	}
 
 	return formattedPaginatedComments, nil
 }
-}
 
-func LoadCommentsByUserID(ctx context.Context) func(ids []int) ([]model.PaginatedComments, []error){
-	return func(ids []int) ([]model.PaginatedComments, []error) {
-		// format user ids as SQL string param
-		queryParam := utils.FormatSliceForSQLParams(ids)
-
-		// attempt to fetch user comments
-		comments, err := sql_models.Comments(qm.Where("user_id = ANY(?::int[]) AND deleted = false", queryParam)).All(ctx, database.DB)
-		if err != nil {
-			return nil, []error{err}
-		}
-
-		// get the comment id for each comment found
-		var currentCommentIDList []int
-	for _, value := range comments {
-		currentCommentIDList = append(currentCommentIDList, value.CommentID)
-	}
-
-	// format comment ids as SQL string param
-	queryParam = utils.FormatSliceForSQLParams(currentCommentIDList)
-	// check if subcomments exist that reference the current comment ids as a parent
-	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
-	if err != nil {
-		return nil, []error{err}
+// load subcomments for each comment id
+func LoadCommentsByCommentID(ctx context.Context) func(keys []CommentsKey) ([]model.PaginatedComments, []error){
+	return func(keys []CommentsKey) ([]model.PaginatedComments, []error) {
+		return loadCommentPages(ctx, keys, "response_to_comment_id", "deleted_at IS NULL")
 	}
+}
 
-	// format comments for graphQL response and note if they have subcomments
-	var formattedComments []model.Comment
-	for _, comment := range comments {
-		// check if comment has subcomments
-		hasSubComment := false
-		for _, subComment := range subComments {
-			if subComment.ResponseToCommentID.Int == comment.CommentID {
-				hasSubComment = true
-			}
-		}
-		fmtComment := utils.ConvertComment(comment, hasSubComment)
-		formattedComments = append(formattedComments, fmtComment)
+// load top-level comments for each post id
+func LoadCommentsByPostID(ctx context.Context) func(keys []CommentsKey) ([]model.PaginatedComments, []error){
+	return func(keys []CommentsKey) ([]model.PaginatedComments, []error) {
+		return loadCommentPages(ctx, keys, "post_id", "response_to_comment_id IS NULL AND deleted_at IS NULL")
 	}
-	
-	// sort formatted comments by post ids from field resolver args
-	// and format each association as a PaginatedComments response
-	var formattedPaginatedComments []model.PaginatedComments
-	for _, userID := range ids {
-		var commentsByUserID []*model.Comment
-		for _, comment := range formattedComments {
-			if comment.UserID == userID {
-				// preserve the current comments data before comment is updated
-				currentComment := *&comment
-				commentsByUserID = append(commentsByUserID, &currentComment)
-			}
-			
-		}
+}
 
-		paginatedResponse := model.PaginatedComments{
-			Comments: commentsByUserID,
-			More: false,
-		}
-		formattedPaginatedComments = append(formattedPaginatedComments, paginatedResponse)
+// load comments written by each user id, skipping comments on posts readers cannot see
+func LoadCommentsByUserID(ctx context.Context) func(keys []CommentsKey) ([]model.PaginatedComments, []error){
+	return func(keys []CommentsKey) ([]model.PaginatedComments, []error) {
+		return loadCommentPages(ctx, keys, "user_id", "deleted_at IS NULL AND "+utils.VisiblePostCommentsClause)
 	}
-
-	return formattedPaginatedComments, nil
-}
 }
 
 func LoadVotesByCommentID(ctx context.Context) func(ids []int) ([]model.Votes, []error){
@@ -257,8 +230,11 @@ func LoadVotesByCommentID(ctx context.Context) func(ids []int) ([]model.Votes, [
 		// format ids as SQL string param
 		queryParam := utils.FormatSliceForSQLParams(ids)
 
-		// attempt to fetch users
-		commentVotes, err := sql_models.CommentVotes(qm.Where("comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
+		// attempt to fetch the vote counters kept on each comment
+		comments, err := sql_models.Comments(
+			qm.Select("comment_id", "upvotes", "downvotes"),
+			qm.Where("comment_id = ANY(?::int[])", queryParam),
+		).All(ctx, database.DB)
 		
 		// format error
 		formattedErrors := []error{err}
@@ -266,18 +242,14 @@ func LoadVotesByCommentID(ctx context.Context) func(ids []int) ([]model.Votes, [
 			return nil, formattedErrors
 		}
 		
-		// total upvote and downvote counts by comment_id
+		// upvote and downvote counts by comment_id
 		voteCounts := make([]model.Votes, len(ids))
-		for i, commentID := range ids {
+		for i, id := range ids {
 			votes := model.Votes{}
-			for _, singleVote := range commentVotes {
-				if singleVote.CommentID == commentID {
-					if singleVote.VoteValue == -1 {
-						votes.Downvote++
-					}
-					if singleVote.VoteValue == 1 {
-						votes.Upvote++
-					}
+			for _, single := range comments {
+				if single.CommentID == id {
+					votes.Upvote = single.Upvotes
+					votes.Downvote = single.Downvotes
 				}
 			}
 			voteCounts[i] = votes
@@ -293,8 +265,11 @@ func LoadVotesByPostID(ctx context.Context) func(ids []int) ([]model.Votes, []er
 		// format ids as SQL string param
 		queryParam := utils.FormatSliceForSQLParams(ids)
 
-		// attempt to fetch users
-		postVotes, err := sql_models.PostVotes(qm.Where("post_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
+		// attempt to fetch the vote counters kept on each post
+		posts, err := sql_models.Posts(
+			qm.Select("post_id", "upvotes", "downvotes"),
+			qm.Where("post_id = ANY(?::int[])", queryParam),
+		).All(ctx, database.DB)
 		
 		// format error
 		formattedErrors := []error{err}
@@ -302,18 +277,14 @@ func LoadVotesByPostID(ctx context.Context) func(ids []int) ([]model.Votes, []er
 			return nil, formattedErrors
 		}
 		
-		// total upvote and downvote counts by comment_id
+		// upvote and downvote counts by post_id
 		voteCounts := make([]model.Votes, len(ids))
-		for i, postID := range ids {
+		for i, id := range ids {
 			votes := model.Votes{}
-			for _, singleVote := range postVotes {
-				if singleVote.PostID == postID {
-					if singleVote.VoteValue == -1 {
-						votes.Downvote++
-					}
-					if singleVote.VoteValue == 1 {
-						votes.Upvote++
-					}
+			for _, single := range posts {
+				if single.PostID == id {
+					votes.Upvote = single.Upvotes
+					votes.Downvote = single.Downvotes
 				}
 			}
 			voteCounts[i] = votes
@@ -324,6 +295,103 @@ func LoadVotesByPostID(ctx context.Context) func(ids []int) ([]model.Votes, []er
 }
 }
 
+func LoadTagsByPostID(ctx context.Context) func(ids []int) ([][]string, []error){
+	return func(ids []int) ([][]string, []error) {
+		// format ids as SQL string param
+		queryParam := utils.FormatSliceForSQLParams(ids)
+
+		// attempt to fetch tag names for each post
+		var postTags []postTag
+		err := queries.Raw(`SELECT pt.post_id, t.tag_name FROM post_tags pt
+			INNER JOIN tags t ON t.tag_id = pt.tag_id
+			WHERE pt.post_id = ANY($1::int[])
+			ORDER BY t.tag_name`, queryParam).Bind(ctx, database.DB, &postTags)
+		if err != nil {
+			return nil, []error{err}
+		}
+
+		// group tag names by post_id according to order of ids in dataloader arg
+		tagsByPostID := make([][]string, len(ids))
+		for i, postID := range ids {
+			tags := []string{}
+			for _, postTag := range postTags {
+				if postTag.PostID == postID {
+					tags = append(tags, postTag.TagName)
+				}
+			}
+			tagsByPostID[i] = tags
+		}
+
+		// return sorted tags
+		return tagsByPostID, nil
+}
+}
+
+// split vote keys into matching user and item id lists for an unnest() query
+func formatVoteKeysForSQLParams(keys []VoteKey) (string, string) {
+	userIDs := make([]int, len(keys))
+	ids := make([]int, len(keys))
+	for i, key := range keys {
+		userIDs[i] = key.UserID
+		ids[i] = key.ID
+	}
+	return utils.FormatSliceForSQLParams(userIDs), utils.FormatSliceForSQLParams(ids)
+}
+
+func LoadMyVotesByPostID(ctx context.Context) func(keys []VoteKey) ([]model.VoteValue, []error){
+	return func(keys []VoteKey) ([]model.VoteValue, []error) {
+		// format user and post ids as SQL string params
+		userIDs, postIDs := formatVoteKeysForSQLParams(keys)
+
+		// attempt to fetch the votes for each user and post pair
+		postVotes, err := sql_models.PostVotes(qm.Where("(user_id, post_id) IN (SELECT * FROM unnest(?::int[], ?::int[]))", userIDs, postIDs)).All(ctx, database.DB)
+		if err != nil {
+			return nil, []error{err}
+		}
+
+		// sort votes according to order of keys in dataloader arg
+		// with no vote on record treated as neutral
+		voteValues := make([]model.VoteValue, len(keys))
+		for i, key := range keys {
+			voteValues[i] = model.VoteValueNeutral
+			for _, postVote := range postVotes {
+				if postVote.UserID == key.UserID && postVote.PostID == key.ID {
+					voteValues[i] = utils.ConvertSQLVoteValueEnums(postVote.VoteValue)
+				}
+			}
+		}
+
+		return voteValues, nil
+}
+}
+
+func LoadMyVotesByCommentID(ctx context.Context) func(keys []VoteKey) ([]model.VoteValue, []error){
+	return func(keys []VoteKey) ([]model.VoteValue, []error) {
+		// format user and comment ids as SQL string params
+		userIDs, commentIDs := formatVoteKeysForSQLParams(keys)
+
+		// attempt to fetch the votes for each user and comment pair
+		commentVotes, err := sql_models.CommentVotes(qm.Where("(user_id, comment_id) IN (SELECT * FROM unnest(?::int[], ?::int[]))", userIDs, commentIDs)).All(ctx, database.DB)
+		if err != nil {
+			return nil, []error{err}
+		}
+
+		// sort votes according to order of keys in dataloader arg
+		// with no vote on record treated as neutral
+		voteValues := make([]model.VoteValue, len(keys))
+		for i, key := range keys {
+			voteValues[i] = model.VoteValueNeutral
+			for _, commentVote := range commentVotes {
+				if commentVote.UserID == key.UserID && commentVote.CommentID == key.ID {
+					voteValues[i] = utils.ConvertSQLVoteValueEnums(commentVote.VoteValue)
+				}
+			}
+		}
+
+		return voteValues, nil
+}
+}
+
 func UseDataLoaders() gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
 
@@ -359,6 +427,21 @@ func UseDataLoaders() gin.HandlerFunc {
 				wait:     1 * time.Millisecond,
 				fetch: LoadVotesByPostID(ginContext),
 			},
+			TagsByPostID: TagsLoader{
+				maxBatch: 100,
+				wait:     1 * time.Millisecond,
+				fetch: LoadTagsByPostID(ginContext),
+			},
+			MyVoteByPostID: VoteValueLoader{
+				maxBatch: 100,
+				wait:     1 * time.Millisecond,
+				fetch: LoadMyVotesByPostID(ginContext),
+			},
+			MyVoteByCommentID: VoteValueLoader{
+				maxBatch: 100,
+				wait:     1 * time.Millisecond,
+				fetch: LoadMyVotesByCommentID(ginContext),
+			},
 		})
 
 		// and call next with our new context
@@ -12,7 +12,7 @@ import (
 // PaginatedCommentsLoaderConfig captures the config to create a new PaginatedCommentsLoader
 type PaginatedCommentsLoaderConfig struct {
 	// Fetch is a method that provides the data for the loader
-	Fetch func(keys []int) ([]model.PaginatedComments, []error)
+	Fetch func(keys []CommentsKey) ([]model.PaginatedComments, []error)
 
 	// Wait is how long wait before sending a batch
 	Wait time.Duration
@@ -33,7 +33,7 @@ func NewPaginatedCommentsLoader(config PaginatedCommentsLoaderConfig) *Paginated
 // PaginatedCommentsLoader batches and caches requests
 type PaginatedCommentsLoader struct {
 	// this method provides the data for the loader
-	fetch func(keys []int) ([]model.PaginatedComments, []error)
+	fetch func(keys []CommentsKey) ([]model.PaginatedComments, []error)
 
 	// how long to done before sending a batch
 	wait time.Duration
@@ -44,7 +44,7 @@ type PaginatedCommentsLoader struct {
 	// INTERNAL
 
 	// lazily created cache
-	cache map[int]model.PaginatedComments
+	cache map[CommentsKey]model.PaginatedComments
 
 	// the current batch. keys will continue to be collected until timeout is hit,
 	// then everything will be sent to the fetch method and out to the listeners
@@ -55,7 +55,7 @@ type PaginatedCommentsLoader struct {
 }
 
 type paginatedCommentsLoaderBatch struct {
-	keys    []int
+	keys    []CommentsKey
 	data    []model.PaginatedComments
 	error   []error
 	closing bool
@@ -63,14 +63,14 @@ type paginatedCommentsLoaderBatch struct {
 }
 
 // Load a PaginatedComments by key, batching and caching will be applied automatically
-func (l *PaginatedCommentsLoader) Load(key int) (model.PaginatedComments, error) {
+func (l *PaginatedCommentsLoader) Load(key CommentsKey) (model.PaginatedComments, error) {
 	return l.LoadThunk(key)()
 }
 
 // LoadThunk returns a function that when called will block waiting for a PaginatedComments.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *PaginatedCommentsLoader) LoadThunk(key int) func() (model.PaginatedComments, error) {
+func (l *PaginatedCommentsLoader) LoadThunk(key CommentsKey) func() (model.PaginatedComments, error) {
 	l.mu.Lock()
 	if it, ok := l.cache[key]; ok {
 		l.mu.Unlock()
@@ -113,7 +113,7 @@ func (l *PaginatedCommentsLoader) LoadThunk(key int) func() (model.PaginatedComm
 
 // LoadAll fetches many keys at once. It will be broken into appropriate sized
 // sub batches depending on how the loader is configured
-func (l *PaginatedCommentsLoader) LoadAll(keys []int) ([]model.PaginatedComments, []error) {
+func (l *PaginatedCommentsLoader) LoadAll(keys []CommentsKey) ([]model.PaginatedComments, []error) {
 	results := make([]func() (model.PaginatedComments, error), len(keys))
 
 	for i, key := range keys {
@@ -131,7 +131,7 @@ func (l *PaginatedCommentsLoader) LoadAll(keys []int) ([]model.PaginatedComments
 // LoadAllThunk returns a function that when called will block waiting for a PaginatedCommentss.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *PaginatedCommentsLoader) LoadAllThunk(keys []int) func() ([]model.PaginatedComments, []error) {
+func (l *PaginatedCommentsLoader) LoadAllThunk(keys []CommentsKey) func() ([]model.PaginatedComments, []error) {
 	results := make([]func() (model.PaginatedComments, error), len(keys))
 	for i, key := range keys {
 		results[i] = l.LoadThunk(key)
@@ -149,7 +149,7 @@ func (l *PaginatedCommentsLoader) LoadAllThunk(keys []int) func() ([]model.Pagin
 // Prime the cache with the provided key and value. If the key already exists, no change is made
 // and false is returned.
 // (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
-func (l *PaginatedCommentsLoader) Prime(key int, value model.PaginatedComments) bool {
+func (l *PaginatedCommentsLoader) Prime(key CommentsKey, value model.PaginatedComments) bool {
 	l.mu.Lock()
 	var found bool
 	if _, found = l.cache[key]; !found {
@@ -160,22 +160,22 @@ func (l *PaginatedCommentsLoader) Prime(key int, value model.PaginatedComments)
 }
 
 // Clear the value at key from the cache, if it exists
-func (l *PaginatedCommentsLoader) Clear(key int) {
+func (l *PaginatedCommentsLoader) Clear(key CommentsKey) {
 	l.mu.Lock()
 	delete(l.cache, key)
 	l.mu.Unlock()
 }
 
-func (l *PaginatedCommentsLoader) unsafeSet(key int, value model.PaginatedComments) {
+func (l *PaginatedCommentsLoader) unsafeSet(key CommentsKey, value model.PaginatedComments) {
 	if l.cache == nil {
-		l.cache = map[int]model.PaginatedComments{}
+		l.cache = map[CommentsKey]model.PaginatedComments{}
 	}
 	l.cache[key] = value
 }
 
 // keyIndex will return the location of the key in the batch, if its not found
 // it will add the key to the batch
-func (b *paginatedCommentsLoaderBatch) keyIndex(l *PaginatedCommentsLoader, key int) int {
+func (b *paginatedCommentsLoaderBatch) keyIndex(l *PaginatedCommentsLoader, key CommentsKey) int {
 	for i, existingKey := range b.keys {
 		if key == existingKey {
 			return i
@@ -0,0 +1,221 @@
+// Code generated by github.com/vektah/dataloaden, DO NOT EDIT.
+
+package dataloader
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/graph/model"
+)
+
+// VoteValueLoaderConfig captures the config to create a new VoteValueLoader
+type VoteValueLoaderConfig struct {
+	// Fetch is a method that provides the data for the loader
+	Fetch func(keys []VoteKey) ([]model.VoteValue, []error)
+
+	// Wait is how long wait before sending a batch
+	Wait time.Duration
+
+	// MaxBatch will limit the maximum number of keys to send in one batch, 0 = not limit
+	MaxBatch int
+}
+
+// NewVoteValueLoader creates a new VoteValueLoader given a fetch, wait, and maxBatch
+func NewVoteValueLoader(config VoteValueLoaderConfig) *VoteValueLoader {
+	return &VoteValueLoader{
+		fetch:    config.Fetch,
+		wait:     config.Wait,
+		maxBatch: config.MaxBatch,
+	}
+}
+
+// VoteValueLoader batches and caches requests
+type VoteValueLoader struct {
+	// this method provides the data for the loader
+	fetch func(keys []VoteKey) ([]model.VoteValue, []error)
+
+	// how long to done before sending a batch
+	wait time.Duration
+
+	// this will limit the maximum number of keys to send in one batch, 0 = no limit
+	maxBatch int
+
+	// INTERNAL
+
+	// lazily created cache
+	cache map[VoteKey]model.VoteValue
+
+	// the current batch. keys will continue to be collected until timeout is hit,
+	// then everything will be sent to the fetch method and out to the listeners
+	batch *voteValueLoaderBatch
+
+	// mutex to prevent races
+	mu sync.Mutex
+}
+
+type voteValueLoaderBatch struct {
+	keys    []VoteKey
+	data    []model.VoteValue
+	error   []error
+	closing bool
+	done    chan struct{}
+}
+
+// Load a VoteValue by key, batching and caching will be applied automatically
+func (l *VoteValueLoader) Load(key VoteKey) (model.VoteValue, error) {
+	return l.LoadThunk(key)()
+}
+
+// LoadThunk returns a function that when called will block waiting for a VoteValue.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *VoteValueLoader) LoadThunk(key VoteKey) func() (model.VoteValue, error) {
+	l.mu.Lock()
+	if it, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return func() (model.VoteValue, error) {
+			return it, nil
+		}
+	}
+	if l.batch == nil {
+		l.batch = &voteValueLoaderBatch{done: make(chan struct{})}
+	}
+	batch := l.batch
+	pos := batch.keyIndex(l, key)
+	l.mu.Unlock()
+
+	return func() (model.VoteValue, error) {
+		<-batch.done
+
+		var data model.VoteValue
+		if pos < len(batch.data) {
+			data = batch.data[pos]
+		}
+
+		var err error
+		// its convenient to be able to return a single error for everything
+		if len(batch.error) == 1 {
+			err = batch.error[0]
+		} else if batch.error != nil {
+			err = batch.error[pos]
+		}
+
+		if err == nil {
+			l.mu.Lock()
+			l.unsafeSet(key, data)
+			l.mu.Unlock()
+		}
+
+		return data, err
+	}
+}
+
+// LoadAll fetches many keys at once. It will be broken into appropriate sized
+// sub batches depending on how the loader is configured
+func (l *VoteValueLoader) LoadAll(keys []VoteKey) ([]model.VoteValue, []error) {
+	results := make([]func() (model.VoteValue, error), len(keys))
+
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+
+	voteValues := make([]model.VoteValue, len(keys))
+	errors := make([]error, len(keys))
+	for i, thunk := range results {
+		voteValues[i], errors[i] = thunk()
+	}
+	return voteValues, errors
+}
+
+// LoadAllThunk returns a function that when called will block waiting for a VoteValues.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *VoteValueLoader) LoadAllThunk(keys []VoteKey) func() ([]model.VoteValue, []error) {
+	results := make([]func() (model.VoteValue, error), len(keys))
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+	return func() ([]model.VoteValue, []error) {
+		voteValues := make([]model.VoteValue, len(keys))
+		errors := make([]error, len(keys))
+		for i, thunk := range results {
+			voteValues[i], errors[i] = thunk()
+		}
+		return voteValues, errors
+	}
+}
+
+// Prime the cache with the provided key and value. If the key already exists, no change is made
+// and false is returned.
+// (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
+func (l *VoteValueLoader) Prime(key VoteKey, value model.VoteValue) bool {
+	l.mu.Lock()
+	var found bool
+	if _, found = l.cache[key]; !found {
+		l.unsafeSet(key, value)
+	}
+	l.mu.Unlock()
+	return !found
+}
+
+// Clear the value at key from the cache, if it exists
+func (l *VoteValueLoader) Clear(key VoteKey) {
+	l.mu.Lock()
+	delete(l.cache, key)
+	l.mu.Unlock()
+}
+
+func (l *VoteValueLoader) unsafeSet(key VoteKey, value model.VoteValue) {
+	if l.cache == nil {
+		l.cache = map[VoteKey]model.VoteValue{}
+	}
+	l.cache[key] = value
+}
+
+// keyIndex will return the location of the key in the batch, if its not found
+// it will add the key to the batch
+func (b *voteValueLoaderBatch) keyIndex(l *VoteValueLoader, key VoteKey) int {
+	for i, existingKey := range b.keys {
+		if key == existingKey {
+			return i
+		}
+	}
+
+	pos := len(b.keys)
+	b.keys = append(b.keys, key)
+	if pos == 0 {
+		go b.startTimer(l)
+	}
+
+	if l.maxBatch != 0 && pos >= l.maxBatch-1 {
+		if !b.closing {
+			b.closing = true
+			l.batch = nil
+			go b.end(l)
+		}
+	}
+
+	return pos
+}
+
+func (b *voteValueLoaderBatch) startTimer(l *VoteValueLoader) {
+	time.Sleep(l.wait)
+	l.mu.Lock()
+
+	// we must have hit a batch limit and are already finalizing this batch
+	if b.closing {
+		l.mu.Unlock()
+		return
+	}
+
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.end(l)
+}
+
+func (b *voteValueLoaderBatch) end(l *VoteValueLoader) {
+	b.data, b.error = l.fetch(b.keys)
+	close(b.done)
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	"github.com/jt-rose/clean_blog_server/graph/generated"
+	"github.com/jt-rose/clean_blog_server/graph/model"
+	"github.com/jt-rose/clean_blog_server/middleware"
+	"github.com/jt-rose/clean_blog_server/utils"
+)
+
+// Directives returns the authorization directives declared in schema.graphqls
+// to be passed to generated.Config when the server is created
+func Directives() generated.DirectiveRoot {
+	return generated.DirectiveRoot{
+		Authenticated: authenticated,
+		Can:           can,
+		Owner:         owner,
+		Verified:      verified,
+		Scope:         scope,
+	}
+}
+
+// @authenticated rejects signed out users
+func authenticated(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if middleware.GetUserIDFromContext(ctx) == 0 {
+		return nil, apperrors.Unauthenticated(constants.UNAUTHENTICATED_ERROR_MESSAGE)
+	}
+	return next(ctx)
+}
+
+// @can(permission:) rejects users whose roles do not allow the permission
+func can(ctx context.Context, obj interface{}, next graphql.Resolver, permission model.Permission) (interface{}, error) {
+	_, err := middleware.RequirePermission(ctx, middleware.Permission(permission.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return next(ctx)
+}
+
+// @owner(field:) hides a field from users other than the one whose user_id is stored
+// in the named field of the parent object, admins may see every user's fields
+// the field resolves to null rather than an error, so lists of users can still select it
+func owner(ctx context.Context, obj interface{}, next graphql.Resolver, field string) (interface{}, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, nil
+	}
+
+	ownerID, ok := intFieldByJSONName(obj, field)
+	if !ok {
+		return nil, errors.New("@owner directive could not find int field " + field)
+	}
+	if ownerID == userID {
+		return next(ctx)
+	}
+
+	isAdmin, err := middleware.HasRole(ctx, userID, middleware.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, nil
+	}
+
+	return next(ctx)
+}
+
+// @verified rejects users who have not verified their email address
+func verified(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, apperrors.Unauthenticated(constants.UNAUTHENTICATED_ERROR_MESSAGE)
+	}
+
+	isVerified, err := middleware.IsEmailVerified(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isVerified {
+		return nil, apperrors.Forbidden(constants.EMAIL_NOT_VERIFIED_ERROR_MESSAGE)
+	}
+
+	return next(ctx)
+}
+
+// @scope(scope:) rejects requests using an API token that was not granted the scope
+// requests using the session cookie are not limited by scopes
+func scope(ctx context.Context, obj interface{}, next graphql.Resolver, scope model.APITokenScope) (interface{}, error) {
+	if !middleware.HasAPITokenScope(ctx, utils.APITokenScopeName(scope)) {
+		return nil, apperrors.Forbidden(constants.API_TOKEN_MISSING_SCOPE_ERROR_MESSAGE)
+	}
+	return next(ctx)
+}
+
+// read an int field from a gql model by the name used in the schema,
+// which gqlgen stores in each field's json tag
+func intFieldByJSONName(obj interface{}, name string) (int, bool) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		tag := strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag == name && value.Field(i).Kind() == reflect.Int {
+			return int(value.Field(i).Int()), true
+		}
+	}
+	return 0, false
+}
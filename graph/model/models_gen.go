@@ -9,6 +9,16 @@ import (
 	"time"
 )
 
+type APIToken struct {
+	TokenID    int             `json:"token_id"`
+	Name       string          `json:"name"`
+	Prefix     string          `json:"prefix"`
+	Scopes     []APITokenScope `json:"scopes"`
+	ExpiresAt  *time.Time      `json:"expires_at"`
+	LastUsedAt *time.Time      `json:"last_used_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
 type Comment struct {
 	CommentID           int                `json:"comment_id"`
 	ResponseToCommentID *int               `json:"response_to_comment_id"`
@@ -16,24 +26,99 @@ type Comment struct {
 	UserID              int                `json:"user_id"`
 	User                *User              `json:"user"`
 	CommentText         string             `json:"comment_text"`
+	Format              TextFormat         `json:"format"`
+	HTML                string             `json:"html"`
 	CreatedAt           time.Time          `json:"created_at"`
 	Comments            *PaginatedComments `json:"comments"`
 	Votes               *Votes             `json:"votes"`
+	MyVote              *VoteValue         `json:"myVote"`
 	Deleted             bool               `json:"deleted"`
+	DeletedAt           *time.Time         `json:"deleted_at"`
 	HasSubComments      bool               `json:"hasSubComments"`
 }
 
+type CommentConnection struct {
+	Edges      []*CommentEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type CommentEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Comment `json:"node"`
+}
+
 type CommentSearch struct {
-	ParentID   int        `json:"parent_id"`
-	ParentType ParentType `json:"parent_type"`
-	Offset     int        `json:"offset"`
-	Limit      int        `json:"limit"`
+	ParentID   int         `json:"parent_id"`
+	ParentType ParentType  `json:"parent_type"`
+	Sort       CommentSort `json:"sort"`
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+}
+
+type CommentTree struct {
+	Nodes  []*CommentTreeNode `json:"nodes"`
+	Cursor *string            `json:"cursor"`
+}
+
+type CommentTreeNode struct {
+	Comment       *Comment `json:"comment"`
+	Depth         int      `json:"depth"`
+	Path          []int    `json:"path"`
+	ChildCount    int      `json:"childCount"`
+	RepliesCursor *string  `json:"repliesCursor"`
 }
 
 type CommentVote struct {
 	CommentID int       `json:"comment_id"`
 	VoteValue VoteValue `json:"vote_value"`
 	UserID    int       `json:"user_id"`
+	Votes     *Votes    `json:"votes"`
+}
+
+type DiffLine struct {
+	Operation DiffOperation `json:"operation"`
+	Text      string        `json:"text"`
+}
+
+type FlaggedVote struct {
+	Target    ParentType     `json:"target"`
+	TargetID  int            `json:"target_id"`
+	UserID    int            `json:"user_id"`
+	User      *User          `json:"user"`
+	VoteValue VoteValue      `json:"vote_value"`
+	Reason    VoteFlagReason `json:"reason"`
+	IPAddress *string        `json:"ip_address"`
+	VotedAt   time.Time      `json:"voted_at"`
+	FlaggedAt time.Time      `json:"flagged_at"`
+}
+
+type FlaggedVotes struct {
+	Votes  []*FlaggedVote `json:"votes"`
+	Cursor *string        `json:"cursor"`
+	More   bool           `json:"more"`
+}
+
+type LeaderboardEntry struct {
+	User       *User `json:"user"`
+	Reputation int   `json:"reputation"`
+}
+
+type LoginResult struct {
+	User              *User `json:"user"`
+	TwoFactorRequired bool  `json:"two_factor_required"`
+}
+
+type NewAPIToken struct {
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"api_token"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
 }
 
 type PaginatedComments struct {
@@ -41,6 +126,11 @@ type PaginatedComments struct {
 	More     bool       `json:"more"`
 }
 
+type PaginatedPostRevisions struct {
+	Revisions []*PostRevision `json:"revisions"`
+	More      bool            `json:"more"`
+}
+
 type PaginatedPosts struct {
 	Posts []*Post `json:"posts"`
 	More  bool    `json:"more"`
@@ -52,47 +142,155 @@ type PaginatedUsers struct {
 }
 
 type Post struct {
-	PostID          int                `json:"post_id"`
-	UserID          int                `json:"user_id"`
-	User            *User              `json:"user"`
-	Title           string             `json:"title"`
-	URLEncodedTitle string             `json:"urlEncodedTitle"`
-	Subtitle        string             `json:"subtitle"`
-	PostText        string             `json:"post_text"`
-	CreatedAt       time.Time          `json:"created_at"`
-	Comments        *PaginatedComments `json:"comments"`
-	Votes           *Votes             `json:"votes"`
-	Deleted         bool               `json:"deleted"`
-	Published       bool               `json:"published"`
+	PostID          int                     `json:"post_id"`
+	UserID          int                     `json:"user_id"`
+	User            *User                   `json:"user"`
+	Title           string                  `json:"title"`
+	Slug            string                  `json:"slug"`
+	URLEncodedTitle string                  `json:"urlEncodedTitle"`
+	Subtitle        string                  `json:"subtitle"`
+	PostText        string                  `json:"post_text"`
+	Format          TextFormat              `json:"format"`
+	HTML            string                  `json:"html"`
+	CreatedAt       time.Time               `json:"created_at"`
+	Comments        *PaginatedComments      `json:"comments"`
+	Votes           *Votes                  `json:"votes"`
+	MyVote          *VoteValue              `json:"myVote"`
+	Deleted         bool                    `json:"deleted"`
+	DeletedAt       *time.Time              `json:"deleted_at"`
+	Published       bool                    `json:"published"`
+	PublishAt       *time.Time              `json:"publish_at"`
+	Tags            []string                `json:"tags"`
+	Revisions       *PaginatedPostRevisions `json:"revisions"`
+}
+
+type PostBySlug struct {
+	Post       *Post   `json:"post"`
+	RedirectTo *string `json:"redirectTo"`
+}
+
+type PostConnection struct {
+	Edges      []*PostEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type PostEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Post  `json:"node"`
+}
+
+type PostFilter struct {
+	Title    *string  `json:"title"`
+	Tags     []string `json:"tags"`
+	MatchAll *bool    `json:"match_all"`
 }
 
 type PostInput struct {
-	Title     string  `json:"title"`
-	Subtitle  *string `json:"subtitle"`
-	Text      string  `json:"text"`
-	Published bool    `json:"published"`
+	Title     string      `json:"title"`
+	Subtitle  *string     `json:"subtitle"`
+	Text      string      `json:"text"`
+	Format    *TextFormat `json:"format"`
+	Published bool        `json:"published"`
+	PublishAt *time.Time  `json:"publish_at"`
+	Tags      []string    `json:"tags"`
+}
+
+type PostRevision struct {
+	RevisionID int        `json:"revision_id"`
+	PostID     int        `json:"post_id"`
+	EditorID   int        `json:"editor_id"`
+	Editor     *User      `json:"editor"`
+	Title      string     `json:"title"`
+	Subtitle   string     `json:"subtitle"`
+	PostText   string     `json:"post_text"`
+	Format     TextFormat `json:"format"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
 
 type PostSearch struct {
-	Title  *string `json:"title"`
-	Limit  int     `json:"limit"`
-	Offset int     `json:"offset"`
+	Title    *string  `json:"title"`
+	Tags     []string `json:"tags"`
+	MatchAll *bool    `json:"match_all"`
+	Limit    int      `json:"limit"`
+	Offset   int      `json:"offset"`
 }
 
 type PostVote struct {
 	PostID    int       `json:"post_id"`
 	VoteValue VoteValue `json:"vote_value"`
 	UserID    int       `json:"user_id"`
+	Votes     *Votes    `json:"votes"`
+}
+
+type RankedPosts struct {
+	Posts  []*Post `json:"posts"`
+	Cursor *string `json:"cursor"`
+	More   bool    `json:"more"`
+}
+
+type RevisionDiff struct {
+	PostID   int         `json:"post_id"`
+	From     int         `json:"from"`
+	To       *int        `json:"to"`
+	Title    []*DiffLine `json:"title"`
+	Subtitle []*DiffLine `json:"subtitle"`
+	PostText []*DiffLine `json:"post_text"`
+}
+
+type SearchHit struct {
+	Type     SearchType `json:"type"`
+	Rank     float64    `json:"rank"`
+	Headline string     `json:"headline"`
+	Post     *Post      `json:"post"`
+	Comment  *Comment   `json:"comment"`
+}
+
+type SearchResults struct {
+	Hits   []*SearchHit `json:"hits"`
+	Cursor *string      `json:"cursor"`
+	More   bool         `json:"more"`
+}
+
+type TagCount struct {
+	Tag       string `json:"tag"`
+	PostCount int    `json:"post_count"`
+}
+
+type Trash struct {
+	Posts         []*Post    `json:"posts"`
+	Comments      []*Comment `json:"comments"`
+	RetentionDays int        `json:"retention_days"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
 }
 
 type User struct {
-	UserID    int                `json:"user_id"`
-	Username  string             `json:"username"`
-	Email     string             `json:"email"`
-	Posts     *PaginatedPosts    `json:"posts"`
-	Comments  *PaginatedComments `json:"comments"`
-	CreatedAt time.Time          `json:"created_at"`
-	Active    bool               `json:"active"`
+	UserID           int                `json:"user_id"`
+	Username         string             `json:"username"`
+	Email            *string            `json:"email"`
+	EmailVerified    *bool              `json:"email_verified"`
+	TwoFactorEnabled *bool              `json:"two_factor_enabled"`
+	Posts            *PaginatedPosts    `json:"posts"`
+	PostsConnection  *PostConnection    `json:"postsConnection"`
+	Comments         *PaginatedComments `json:"comments"`
+	CreatedAt        time.Time          `json:"created_at"`
+	Active           bool               `json:"active"`
+	Reputation       int                `json:"reputation"`
+}
+
+type UserConnection struct {
+	Edges      []*UserEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type UserEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
 }
 
 type UserInput struct {
@@ -112,6 +310,182 @@ type Votes struct {
 	Downvote int `json:"downvote"`
 }
 
+type APITokenScope string
+
+const (
+	APITokenScopePostsWrite    APITokenScope = "posts_write"
+	APITokenScopeCommentsWrite APITokenScope = "comments_write"
+)
+
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopePostsWrite,
+	APITokenScopeCommentsWrite,
+}
+
+func (e APITokenScope) IsValid() bool {
+	switch e {
+	case APITokenScopePostsWrite, APITokenScopeCommentsWrite:
+		return true
+	}
+	return false
+}
+
+func (e APITokenScope) String() string {
+	return string(e)
+}
+
+func (e *APITokenScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ApiTokenScope", str)
+	}
+	return nil
+}
+
+func (e APITokenScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CommentSort string
+
+const (
+	CommentSortOld           CommentSort = "old"
+	CommentSortNew           CommentSort = "new"
+	CommentSortTop           CommentSort = "top"
+	CommentSortControversial CommentSort = "controversial"
+	CommentSortHot           CommentSort = "hot"
+)
+
+var AllCommentSort = []CommentSort{
+	CommentSortOld,
+	CommentSortNew,
+	CommentSortTop,
+	CommentSortControversial,
+	CommentSortHot,
+}
+
+func (e CommentSort) IsValid() bool {
+	switch e {
+	case CommentSortOld, CommentSortNew, CommentSortTop, CommentSortControversial, CommentSortHot:
+		return true
+	}
+	return false
+}
+
+func (e CommentSort) String() string {
+	return string(e)
+}
+
+func (e *CommentSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentSort", str)
+	}
+	return nil
+}
+
+func (e CommentSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type DiffOperation string
+
+const (
+	DiffOperationEqual  DiffOperation = "equal"
+	DiffOperationInsert DiffOperation = "insert"
+	DiffOperationDelete DiffOperation = "delete"
+)
+
+var AllDiffOperation = []DiffOperation{
+	DiffOperationEqual,
+	DiffOperationInsert,
+	DiffOperationDelete,
+}
+
+func (e DiffOperation) IsValid() bool {
+	switch e {
+	case DiffOperationEqual, DiffOperationInsert, DiffOperationDelete:
+		return true
+	}
+	return false
+}
+
+func (e DiffOperation) String() string {
+	return string(e)
+}
+
+func (e *DiffOperation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiffOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiffOperation", str)
+	}
+	return nil
+}
+
+func (e DiffOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodDay   LeaderboardPeriod = "day"
+	LeaderboardPeriodWeek  LeaderboardPeriod = "week"
+	LeaderboardPeriodMonth LeaderboardPeriod = "month"
+	LeaderboardPeriodAll   LeaderboardPeriod = "all"
+)
+
+var AllLeaderboardPeriod = []LeaderboardPeriod{
+	LeaderboardPeriodDay,
+	LeaderboardPeriodWeek,
+	LeaderboardPeriodMonth,
+	LeaderboardPeriodAll,
+}
+
+func (e LeaderboardPeriod) IsValid() bool {
+	switch e {
+	case LeaderboardPeriodDay, LeaderboardPeriodWeek, LeaderboardPeriodMonth, LeaderboardPeriodAll:
+		return true
+	}
+	return false
+}
+
+func (e LeaderboardPeriod) String() string {
+	return string(e)
+}
+
+func (e *LeaderboardPeriod) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LeaderboardPeriod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LeaderboardPeriod", str)
+	}
+	return nil
+}
+
+func (e LeaderboardPeriod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ParentType string
 
 const (
@@ -153,6 +527,313 @@ func (e ParentType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type Permission string
+
+const (
+	PermissionWritePosts       Permission = "write_posts"
+	PermissionEditAnyPost      Permission = "edit_any_post"
+	PermissionModerateComments Permission = "moderate_comments"
+	PermissionReviewVotes      Permission = "review_votes"
+	PermissionManageRoles      Permission = "manage_roles"
+)
+
+var AllPermission = []Permission{
+	PermissionWritePosts,
+	PermissionEditAnyPost,
+	PermissionModerateComments,
+	PermissionReviewVotes,
+	PermissionManageRoles,
+}
+
+func (e Permission) IsValid() bool {
+	switch e {
+	case PermissionWritePosts, PermissionEditAnyPost, PermissionModerateComments, PermissionReviewVotes, PermissionManageRoles:
+		return true
+	}
+	return false
+}
+
+func (e Permission) String() string {
+	return string(e)
+}
+
+func (e *Permission) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Permission(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Permission", str)
+	}
+	return nil
+}
+
+func (e Permission) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PostSort string
+
+const (
+	PostSortHot PostSort = "hot"
+	PostSortNew PostSort = "new"
+	PostSortTop PostSort = "top"
+)
+
+var AllPostSort = []PostSort{
+	PostSortHot,
+	PostSortNew,
+	PostSortTop,
+}
+
+func (e PostSort) IsValid() bool {
+	switch e {
+	case PostSortHot, PostSortNew, PostSortTop:
+		return true
+	}
+	return false
+}
+
+func (e PostSort) String() string {
+	return string(e)
+}
+
+func (e *PostSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostSort", str)
+	}
+	return nil
+}
+
+func (e PostSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"
+	RoleAuthor    Role = "author"
+	RoleModerator Role = "moderator"
+	RoleReader    Role = "reader"
+)
+
+var AllRole = []Role{
+	RoleAdmin,
+	RoleAuthor,
+	RoleModerator,
+	RoleReader,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleAdmin, RoleAuthor, RoleModerator, RoleReader:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SearchType string
+
+const (
+	SearchTypePost    SearchType = "post"
+	SearchTypeComment SearchType = "comment"
+)
+
+var AllSearchType = []SearchType{
+	SearchTypePost,
+	SearchTypeComment,
+}
+
+func (e SearchType) IsValid() bool {
+	switch e {
+	case SearchTypePost, SearchTypeComment:
+		return true
+	}
+	return false
+}
+
+func (e SearchType) String() string {
+	return string(e)
+}
+
+func (e *SearchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchType", str)
+	}
+	return nil
+}
+
+func (e SearchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TextFormat string
+
+const (
+	TextFormatMarkdown TextFormat = "markdown"
+	TextFormatHTML     TextFormat = "html"
+	TextFormatPlain    TextFormat = "plain"
+)
+
+var AllTextFormat = []TextFormat{
+	TextFormatMarkdown,
+	TextFormatHTML,
+	TextFormatPlain,
+}
+
+func (e TextFormat) IsValid() bool {
+	switch e {
+	case TextFormatMarkdown, TextFormatHTML, TextFormatPlain:
+		return true
+	}
+	return false
+}
+
+func (e TextFormat) String() string {
+	return string(e)
+}
+
+func (e *TextFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TextFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TextFormat", str)
+	}
+	return nil
+}
+
+func (e TextFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TrendingWindow string
+
+const (
+	TrendingWindowDay   TrendingWindow = "day"
+	TrendingWindowWeek  TrendingWindow = "week"
+	TrendingWindowMonth TrendingWindow = "month"
+)
+
+var AllTrendingWindow = []TrendingWindow{
+	TrendingWindowDay,
+	TrendingWindowWeek,
+	TrendingWindowMonth,
+}
+
+func (e TrendingWindow) IsValid() bool {
+	switch e {
+	case TrendingWindowDay, TrendingWindowWeek, TrendingWindowMonth:
+		return true
+	}
+	return false
+}
+
+func (e TrendingWindow) String() string {
+	return string(e)
+}
+
+func (e *TrendingWindow) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TrendingWindow(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TrendingWindow", str)
+	}
+	return nil
+}
+
+func (e TrendingWindow) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type VoteFlagReason string
+
+const (
+	VoteFlagReasonNewAccountBurst   VoteFlagReason = "new_account_burst"
+	VoteFlagReasonVotingRing        VoteFlagReason = "voting_ring"
+	VoteFlagReasonSharedIP          VoteFlagReason = "shared_ip"
+	VoteFlagReasonSharedFingerprint VoteFlagReason = "shared_fingerprint"
+)
+
+var AllVoteFlagReason = []VoteFlagReason{
+	VoteFlagReasonNewAccountBurst,
+	VoteFlagReasonVotingRing,
+	VoteFlagReasonSharedIP,
+	VoteFlagReasonSharedFingerprint,
+}
+
+func (e VoteFlagReason) IsValid() bool {
+	switch e {
+	case VoteFlagReasonNewAccountBurst, VoteFlagReasonVotingRing, VoteFlagReasonSharedIP, VoteFlagReasonSharedFingerprint:
+		return true
+	}
+	return false
+}
+
+func (e VoteFlagReason) String() string {
+	return string(e)
+}
+
+func (e *VoteFlagReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VoteFlagReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VoteFlagReason", str)
+	}
+	return nil
+}
+
+func (e VoteFlagReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type VoteValue string
 
 const (
@@ -5,12 +5,15 @@ package graph
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/gofrs/uuid"
+	"github.com/jt-rose/clean_blog_server/apperrors"
 	"github.com/jt-rose/clean_blog_server/constants"
 	"github.com/jt-rose/clean_blog_server/graph/generated"
 	"github.com/jt-rose/clean_blog_server/graph/model"
@@ -22,6 +25,7 @@ import (
 	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
 	null "github.com/volatiletech/null/v8"
 	boil "github.com/volatiletech/sqlboiler/v4/boil"
+	queries "github.com/volatiletech/sqlboiler/v4/queries"
 	qm "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
@@ -34,8 +38,8 @@ func (r *commentResolver) User(ctx context.Context, obj *model.Comment) (*model.
 	return &user, err
 }
 
-func (r *commentResolver) Comments(ctx context.Context, obj *model.Comment) (*model.PaginatedComments, error) {
-	paginatedComments, err := dataloader.For(ctx).CommentByCommentID.Load(obj.CommentID)
+func (r *commentResolver) Comments(ctx context.Context, obj *model.Comment, limit int, sort model.CommentSort) (*model.PaginatedComments, error) {
+	paginatedComments, err := dataloader.For(ctx).CommentByCommentID.Load(dataloader.NewCommentsKey(obj.CommentID, limit, sort))
 	return &paginatedComments, err
 }
 
@@ -44,34 +48,78 @@ func (r *commentResolver) Votes(ctx context.Context, obj *model.Comment) (*model
 	return &votes, err
 }
 
+func (r *commentResolver) MyVote(ctx context.Context, obj *model.Comment) (*model.VoteValue, error) {
+	// signed out users have no vote to show
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, nil
+	}
+
+	voteValue, err := dataloader.For(ctx).MyVoteByCommentID.Load(dataloader.VoteKey{UserID: userID, ID: obj.CommentID})
+	return &voteValue, err
+}
+
+func (r *commentResolver) HTML(ctx context.Context, obj *model.Comment) (string, error) {
+	return utils.RenderCachedHTML(ctx, obj.Format, obj.CommentText)
+}
+
+func (r *flaggedVoteResolver) User(ctx context.Context, obj *model.FlaggedVote) (*model.User, error) {
+	user, err := dataloader.For(ctx).UserById.Load(obj.UserID)
+	return &user, err
+}
+
 /* -------------------------------------------------------------------------- */
 /*                                  Post CRUD                                 */
 /* -------------------------------------------------------------------------- */
 
-func (r *mutationResolver) AddPost(ctx context.Context, postInput model.PostInput, authorID int) (*model.Post, error) {
-	// confirm user is the author of the blog
-	isAuthor, userID, err := middleware.ConfirmAuthor(ctx, authorID)
+func (r *mutationResolver) AddPost(ctx context.Context, postInput model.PostInput, authorID *int) (*model.Post, error) {
+	// users without the write_posts permission are rejected by the @can directive
+	// the signed in user is always the author of the new post
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// attenpt to add new post
+	newPost := sql_models.Post{
+		UserID:   userID,
+		Title:    postInput.Title,
+		PostText: postInput.Text,
+		Format:   model.TextFormatHTML.String(),
+	}
+
+	if postInput.Subtitle != nil {
+		newPost.Subtitle = *postInput.Subtitle
+	}
+
+	if postInput.Format != nil {
+		newPost.Format = postInput.Format.String()
+	}
+
+	// set the published status, scheduling the post if publish_at is provided
+	err := utils.SetPublishStatus(&newPost, postInput)
 	if err != nil {
 		return nil, err
 	}
 
-	if !isAuthor {
-		return nil, errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	// insert the post and its tags in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	// attenpt to add new post
-	newPost := sql_models.Post{
-		UserID:    userID,
-		Title:     postInput.Title,
-		PostText:  postInput.Text,
-		Published: postInput.Published,
+	// insert the post with a url-friendly slug that is unique for the author
+	err = utils.SavePostWithSlug(ctx, tx, &newPost, "")
+	if err != nil {
+		return nil, err
 	}
 
-	if postInput.Subtitle != nil {
-		newPost.Subtitle = *postInput.Subtitle
+	if postInput.Tags != nil {
+		err = utils.SetPostTags(ctx, tx, &newPost, postInput.Tags)
+		if err != nil {
+			return nil, apperrors.WithField(err, "postInput.tags")
+		}
 	}
 
-	err = newPost.Insert(ctx, database.DB, boil.Infer())
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -81,29 +129,70 @@ func (r *mutationResolver) AddPost(ctx context.Context, postInput model.PostInpu
 	return &gql_post, nil
 }
 
-func (r *mutationResolver) EditPost(ctx context.Context, postID int, postInput model.PostInput, authorID int) (*model.Post, error) {
-	// confirm user is the author of the blog
-	isAuthor, _, err := middleware.ConfirmAuthor(ctx, authorID)
+func (r *mutationResolver) EditPost(ctx context.Context, postID int, postInput model.PostInput, authorID *int) (*model.Post, error) {
+	// snapshot the previous version and update the post in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	if !isAuthor {
-		return nil, errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	// attempt to update the post in the database
+	// posts in the trash must be restored before they can be edited
+	currentPost, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID), qm.For("UPDATE")).One(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
 
-	// attempt to update the post in the database
-	currentPost, err := sql_models.FindPost(ctx, database.DB, postID)
+	// confirm user is allowed to edit this post
+	userID, err := middleware.RejectIfCannotEditPost(ctx, currentPost)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the previous version of the post in the revision history
+	revision := sql_models.PostRevision{
+		PostID:   currentPost.PostID,
+		EditorID: userID,
+		Title:    currentPost.Title,
+		Subtitle: currentPost.Subtitle,
+		PostText: currentPost.PostText,
+		Format:   currentPost.Format,
+	}
+	err = revision.Insert(ctx, tx, boil.Infer())
 	if err != nil {
 		return nil, err
 	}
 
+	previousTitle := currentPost.Title
 	currentPost.Title = postInput.Title
-	currentPost.Subtitle = *postInput.Subtitle
+	if postInput.Subtitle != nil {
+		currentPost.Subtitle = *postInput.Subtitle
+	}
 	currentPost.PostText = postInput.Text
-	currentPost.Published = postInput.Published
+	if postInput.Format != nil {
+		currentPost.Format = postInput.Format.String()
+	}
+	err = utils.SetPublishStatus(currentPost, postInput)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err = currentPost.Update(ctx, database.DB, boil.Infer())
+	// save the post, updating the slug if the title changed and keeping the old slug for redirects
+	err = utils.SavePostWithSlug(ctx, tx, currentPost, previousTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	// replace the tags on the post only when new tags are provided
+	if postInput.Tags != nil {
+		err = utils.SetPostTags(ctx, tx, currentPost, postInput.Tags)
+		if err != nil {
+			return nil, apperrors.WithField(err, "postInput.tags")
+		}
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -113,68 +202,157 @@ func (r *mutationResolver) EditPost(ctx context.Context, postID int, postInput m
 	return &gql_post, nil
 }
 
-func (r *mutationResolver) DeletePost(ctx context.Context, postID int, authorID int) (bool, error) {
-	// confirm user is the author of the blog
-	isAuthor, _, err := middleware.ConfirmAuthor(ctx, authorID)
+func (r *mutationResolver) DeletePost(ctx context.Context, postID int, authorID *int) (bool, error) {
+	post, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID)).One(ctx, database.DB)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
 
-	if !isAuthor {
-		return false, errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	// confirm user is allowed to delete this post
+	_, err = middleware.RejectIfCannotEditPost(ctx, post)
+	if err != nil {
+		return false, err
 	}
 
-	// attempt to update the deleted property to true
-	_, err = sql_models.Posts(qm.Where("post_id = ?", postID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted": true})
-
+	// attempt to move the post to the trash by setting deleted_at
+	// the post will be permanently purged once the retention period has passed
+	rowsAff, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted_at": time.Now()})
 	if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	return rowsAff == 1, nil
 }
 
-func (r *mutationResolver) RestorePost(ctx context.Context, postID int, authorID int) (bool, error) {
-	// confirm user is the author of the blog
-	isAuthor, _, err := middleware.ConfirmAuthor(ctx, authorID)
+func (r *mutationResolver) RestorePost(ctx context.Context, postID int, authorID *int) (bool, error) {
+	post, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NOT NULL", postID)).One(ctx, database.DB)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// confirm user is allowed to restore this post
+	_, err = middleware.RejectIfCannotEditPost(ctx, post)
 	if err != nil {
 		return false, err
 	}
 
-	if !isAuthor {
-		return false, errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	// attempt to restore post by clearing deleted_at
+	rowsAff, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NOT NULL", postID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted_at": nil})
+	if err != nil {
+		return false, err
 	}
 
-	// attempt to restore post by updating deleted property to false
-	_, err = sql_models.Posts(qm.Where("post_id = ?", postID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted": false})
+	return rowsAff == 1, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               post revisions                               */
+/* -------------------------------------------------------------------------- */
+
+func (r *mutationResolver) RestoreRevision(ctx context.Context, postID int, revisionID int) (*model.Post, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
+	currentPost, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID), qm.For("UPDATE")).One(ctx, tx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	return true, nil
+	// only users allowed to edit the post may roll it back
+	userID, err := middleware.RejectIfCannotEditPost(ctx, currentPost)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := sql_models.PostRevisions(qm.Where("revision_id = ? AND post_id = ?", revisionID, postID)).One(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// snapshot the current version first so that the rollback can be undone
+	snapshot := sql_models.PostRevision{
+		PostID:   currentPost.PostID,
+		EditorID: userID,
+		Title:    currentPost.Title,
+		Subtitle: currentPost.Subtitle,
+		PostText: currentPost.PostText,
+		Format:   currentPost.Format,
+	}
+	err = snapshot.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return nil, err
+	}
+
+	previousTitle := currentPost.Title
+	currentPost.Title = revision.Title
+	currentPost.Subtitle = revision.Subtitle
+	currentPost.PostText = revision.PostText
+	currentPost.Format = revision.Format
+
+	// save the post, updating the slug if the title changed and keeping the old slug for redirects
+	err = utils.SavePostWithSlug(ctx, tx, currentPost, previousTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	// return gql version of the restored post
+	gql_post := utils.ConvertPost(currentPost)
+	return &gql_post, nil
 }
 
 /* -------------------------------------------------------------------------- */
 /*                                comment CRUD                                */
 /* -------------------------------------------------------------------------- */
 
-func (r *mutationResolver) AddComment(ctx context.Context, postID int, responseToCommentID *int, commentText string) (*model.Comment, error) {
-	// confirm authenticated
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+func (r *mutationResolver) AddComment(ctx context.Context, postID int, responseToCommentID *int, commentText string, format *model.TextFormat) (*model.Comment, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// comments can only be added to posts visible to readers
+	postExists, err := sql_models.Posts(qm.Where("post_id = ? AND "+utils.VisiblePostClause, postID)).Exists(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
+	if !postExists {
+		return nil, apperrors.NotFound(constants.POST_NOT_FOUND_ERROR_MESSAGE)
+	}
+
+	// replies must be to a comment on the same post
+	if responseToCommentID != nil {
+		parentExists, err := sql_models.Comments(qm.Where("comment_id = ? AND post_id = ? AND deleted_at IS NULL", *responseToCommentID, postID)).Exists(ctx, database.DB)
+		if err != nil {
+			return nil, err
+		}
+		if !parentExists {
+			return nil, apperrors.NotFound(constants.COMMENT_NOT_FOUND_ERROR_MESSAGE)
+		}
+	}
 
 	// attempt to add comment to database
 	newComment := sql_models.Comment{
-		UserID: userID,
-		PostID: postID,
-		ResponseToCommentID: null.Int{
-			Int:   *responseToCommentID,
-			Valid: *responseToCommentID == 0,
-		},
-		CommentText: commentText,
+		UserID:              userID,
+		PostID:              postID,
+		ResponseToCommentID: null.IntFromPtr(responseToCommentID),
+		CommentText:         commentText,
+		Format:              model.TextFormatPlain.String(),
+	}
+
+	if format != nil {
+		newComment.Format = format.String()
 	}
 
 	err = newComment.Insert(ctx, database.DB, boil.Infer())
@@ -187,34 +365,37 @@ func (r *mutationResolver) AddComment(ctx context.Context, postID int, responseT
 	return &gql_comment, nil
 }
 
-func (r *mutationResolver) EditComment(ctx context.Context, commentID int, newCommentText string) (*model.Comment, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
-	if err != nil {
-		return nil, err
-	}
+func (r *mutationResolver) EditComment(ctx context.Context, commentID int, newCommentText string, format *model.TextFormat) (*model.Comment, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
 
 	// confirm user is author of comment
-	comment, err := sql_models.Comments(qm.Where("comment_id = ?", commentID)).One(ctx, database.DB)
+	// comments in the trash must be restored before they can be edited
+	comment, err := sql_models.Comments(qm.Where("comment_id = ? AND deleted_at IS NULL", commentID)).One(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
 
 	// reject if not the author of the comment
 	if comment.UserID != userID {
-		err = errors.New(constants.ONLY_COMMENT_AUTHOR_MAY_EDIT)
+		err = apperrors.Forbidden(constants.ONLY_COMMENT_AUTHOR_MAY_EDIT)
 		return nil, err
 	}
 
+	// keep the current format unless a new one is provided
+	if format != nil {
+		comment.Format = format.String()
+	}
+
 	// attempt to edit the comment in the database
-	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"comment_text": newCommentText})
+	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"comment_text": newCommentText, "format": comment.Format})
 	if err != nil {
 		return nil, err
 	}
 
 	// return gql version of updated comment
 	// there is the small
-	hasSubComments, _ := sql_models.Comments(qm.Where("response_to_comment_id = ?", commentID)).Exists(ctx, database.DB)
+	hasSubComments, _ := sql_models.Comments(qm.Where("response_to_comment_id = ? AND deleted_at IS NULL", commentID)).Exists(ctx, database.DB)
 
 	// if the above query has an error, we will simply use the zero value for hasSubComments
 	gql_comment := utils.ConvertComment(comment, hasSubComments)
@@ -223,60 +404,60 @@ func (r *mutationResolver) EditComment(ctx context.Context, commentID int, newCo
 }
 
 func (r *mutationResolver) DeleteComment(ctx context.Context, commentID int) (bool, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// find the comment to check who may change it
+	comment, err := sql_models.Comments(qm.Where("comment_id = ? AND deleted_at IS NULL", commentID)).One(ctx, database.DB)
 	if err != nil {
 		return false, err
 	}
 
-	// confirm user is author of comment
-	comment, err := sql_models.Comments(qm.Where("comment_id = ?", commentID)).One(ctx, database.DB)
+	// reject if not the author of the comment or a moderator
+	allowed, err := middleware.CanModerateComment(ctx, userID, comment)
 	if err != nil {
 		return false, err
 	}
-
-	// reject if not the author of the comment
-	if comment.UserID != userID {
-		err = errors.New(constants.ONLY_COMMENT_AUTHOR_MAY_EDIT)
-		return false, err
+	if !allowed {
+		return false, apperrors.Forbidden(constants.ONLY_COMMENT_AUTHOR_OR_MODERATOR_ALLOWED)
 	}
 
-	// attempt to delete the comment in the database
-	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted": false})
+	// attempt to move the comment to the trash by setting deleted_at
+	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted_at": time.Now()})
 	if err != nil {
 		return false, err
 	}
 
-	// return boolean confirming successful restoration
+	// return boolean confirming successful deletion
 	return true, nil
 }
 
 func (r *mutationResolver) RestoreComment(ctx context.Context, commentID int) (bool, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// find the comment to check who may change it
+	comment, err := sql_models.Comments(qm.Where("comment_id = ? AND deleted_at IS NOT NULL", commentID)).One(ctx, database.DB)
 	if err != nil {
 		return false, err
 	}
 
-	// confirm user is author of comment
-	comment, err := sql_models.Comments(qm.Where("comment_id = ?", commentID)).One(ctx, database.DB)
+	// reject if not the author of the comment or a moderator
+	allowed, err := middleware.CanModerateComment(ctx, userID, comment)
 	if err != nil {
 		return false, err
 	}
-
-	// reject if not the author of the comment
-	if comment.UserID != userID {
-		err = errors.New(constants.ONLY_COMMENT_AUTHOR_MAY_EDIT)
-		return false, err
+	if !allowed {
+		return false, apperrors.Forbidden(constants.ONLY_COMMENT_AUTHOR_OR_MODERATOR_ALLOWED)
 	}
 
-	// attempt to delete the comment in the database
-	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted": true})
+	// attempt to restore the comment by clearing deleted_at
+	_, err = sql_models.Comments(qm.Where("comment_id = ?", commentID)).UpdateAll(ctx, database.DB, sql_models.M{"deleted_at": nil})
 	if err != nil {
 		return false, err
 	}
 
-	// return boolean confirming successful deletion
+	// return boolean confirming successful restoration
 	return true, nil
 }
 
@@ -285,83 +466,201 @@ func (r *mutationResolver) RestoreComment(ctx context.Context, commentID int) (b
 /* -------------------------------------------------------------------------- */
 
 func (r *mutationResolver) VoteOnPost(ctx context.Context, postID int, voteValue model.VoteValue) (*model.PostVote, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// record the vote and update the post's vote counters in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// lock the post so concurrent votes on it apply their counter changes one at a time
+	// only posts visible to readers can be voted on
+	post, err := sql_models.Posts(
+		qm.Select("post_id", "user_id"),
+		qm.Where("post_id = ? AND "+utils.VisiblePostClause, postID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound(constants.POST_NOT_FOUND_ERROR_MESSAGE)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// check if user has already voted
-	currentPostVote, err := sql_models.FindPostVote(ctx, database.DB, postID, userID)
+	// find the user's previous vote, if any, to work out the change to the counters
+	oldVoteValue := 0
+	previousVote, err := sql_models.FindPostVote(ctx, tx, postID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if previousVote != nil {
+		oldVoteValue = previousVote.VoteValue
+	}
 
+	// record where the vote came from for the vote analyzer
+	ipAddress, fingerprint, err := middleware.GetClientFingerprint(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// attempt to add new vote or update existing vote
-	if currentPostVote == nil {
-		currentPostVote = &sql_models.PostVote{
-			PostID:    postID,
-			UserID:    userID,
-			VoteValue: utils.ConvertGQLVoteValueEnums(voteValue),
-		}
-		err = currentPostVote.Insert(ctx, database.DB, boil.Infer())
-		if err != nil {
-			return nil, err
-		}
+	// add a new vote or update the existing one in a single statement
+	currentPostVote := &sql_models.PostVote{
+		PostID:      postID,
+		UserID:      userID,
+		VoteValue:   utils.ConvertGQLVoteValueEnums(voteValue),
+		IPAddress:   null.NewString(ipAddress, ipAddress != ""),
+		Fingerprint: null.StringFrom(fingerprint),
+	}
+	err = currentPostVote.Upsert(ctx, tx, true, []string{"post_id", "user_id"}, boil.Whitelist("vote_value", "updated_at", "ip_address", "fingerprint"), boil.Infer())
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
-		currentPostVote.VoteValue = utils.ConvertGQLVoteValueEnums(voteValue)
-		_, err = currentPostVote.Update(ctx, database.DB, boil.Infer())
-		if err != nil {
-			return nil, err
-		}
+	// apply only the change between the old and new vote to the counters
+	// and the author's reputation, and read back the updated totals
+	// a flagged vote stays flagged when changed, and is left out of both until reinstated
+	newVoteValue := currentPostVote.VoteValue
+	if previousVote != nil && previousVote.FlaggedAt.Valid {
+		oldVoteValue, newVoteValue = 0, 0
+	}
+	votes, err := utils.ApplyVoteChange(ctx, tx, model.ParentTypePost, postID, post.UserID, userID, oldVoteValue, newVoteValue)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
 	}
 
-	// return vote object
+	// return vote object with the updated totals
 	gql_postVote := utils.ConvertPostVote(currentPostVote)
+	gql_postVote.Votes = votes
 	return &gql_postVote, nil
 }
 
 func (r *mutationResolver) VoteOnComment(ctx context.Context, commentID int, voteValue model.VoteValue) (*model.CommentVote, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// record the vote and update the comment's vote counters in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// lock the comment so concurrent votes on it apply their counter changes one at a time
+	// only comments on posts visible to readers can be voted on
+	comment, err := sql_models.Comments(
+		qm.Select("comment_id", "user_id"),
+		qm.Where("comment_id = ? AND deleted_at IS NULL", commentID),
+		qm.Where(utils.VisiblePostCommentsClause),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound(constants.COMMENT_NOT_FOUND_ERROR_MESSAGE)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// check if user has already voted
-	currentCommentVote, err := sql_models.FindCommentVote(ctx, database.DB, commentID, userID)
+	// find the user's previous vote, if any, to work out the change to the counters
+	oldVoteValue := 0
+	previousVote, err := sql_models.FindCommentVote(ctx, tx, commentID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if previousVote != nil {
+		oldVoteValue = previousVote.VoteValue
+	}
 
+	// record where the vote came from for the vote analyzer
+	ipAddress, fingerprint, err := middleware.GetClientFingerprint(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// attempt to add new vote or update existing vote
-	if currentCommentVote == nil {
-		currentCommentVote = &sql_models.CommentVote{
-			CommentID: commentID,
-			UserID:    userID,
-			VoteValue: utils.ConvertGQLVoteValueEnums(voteValue),
-		}
-		err = currentCommentVote.Insert(ctx, database.DB, boil.Infer())
-		if err != nil {
-			return nil, err
-		}
+	// add a new vote or update the existing one in a single statement
+	currentCommentVote := &sql_models.CommentVote{
+		CommentID:   commentID,
+		UserID:      userID,
+		VoteValue:   utils.ConvertGQLVoteValueEnums(voteValue),
+		IPAddress:   null.NewString(ipAddress, ipAddress != ""),
+		Fingerprint: null.StringFrom(fingerprint),
+	}
+	err = currentCommentVote.Upsert(ctx, tx, true, []string{"comment_id", "user_id"}, boil.Whitelist("vote_value", "updated_at", "ip_address", "fingerprint"), boil.Infer())
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
-		currentCommentVote.VoteValue = utils.ConvertGQLVoteValueEnums(voteValue)
-		_, err = currentCommentVote.Update(ctx, database.DB, boil.Infer())
-		if err != nil {
-			return nil, err
-		}
+	// apply only the change between the old and new vote to the counters
+	// and the author's reputation, and read back the updated totals
+	// a flagged vote stays flagged when changed, and is left out of both until reinstated
+	newVoteValue := currentCommentVote.VoteValue
+	if previousVote != nil && previousVote.FlaggedAt.Valid {
+		oldVoteValue, newVoteValue = 0, 0
+	}
+	votes, err := utils.ApplyVoteChange(ctx, tx, model.ParentTypeComment, commentID, comment.UserID, userID, oldVoteValue, newVoteValue)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
 	}
 
-	// return vote object
+	// return vote object with the updated totals
 	gql_commentVote := utils.ConvertCommentVote(currentCommentVote)
+	gql_commentVote.Votes = votes
 	return &gql_commentVote, nil
 }
 
+func (r *mutationResolver) ReinstateVote(ctx context.Context, target model.ParentType, targetID int, userID int) (*model.Votes, error) {
+	// clear the flag and add the vote back into the totals in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	votes, err := utils.ReinstateVote(ctx, tx, target, targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                manage roles                                */
+/* -------------------------------------------------------------------------- */
+
+func (r *mutationResolver) GrantRole(ctx context.Context, userID int, role model.Role) (bool, error) {
+	return utils.GrantRole(ctx, database.DB, userID, role.String())
+}
+
+func (r *mutationResolver) RevokeRole(ctx context.Context, userID int, role model.Role) (bool, error) {
+	// users without the manage_roles permission are rejected by the @can directive
+	adminID := middleware.GetUserIDFromContext(ctx)
+
+	// prevent admins from locking themselves out of role management
+	if adminID == userID && role == model.RoleAdmin {
+		return false, apperrors.Forbidden(constants.CANNOT_REVOKE_OWN_ADMIN_ERROR_MESSAGE)
+	}
+
+	return utils.RevokeRole(ctx, database.DB, userID, role.String())
+}
+
 /* -------------------------------------------------------------------------- */
 /*                                manage users                                */
 /* -------------------------------------------------------------------------- */
@@ -370,15 +669,15 @@ func (r *mutationResolver) RegisterNewUser(ctx context.Context, userInput model.
 	// validate user inputs
 	err := utils.ValidateEmail(userInput.Email)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.WithField(err, "userInput.email")
 	}
 	err = utils.ValidateUsername(userInput.Username)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.WithField(err, "userInput.username")
 	}
 	err = utils.ValidatePassword(userInput.Password)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.WithField(err, "userInput.password")
 	}
 
 	// get session
@@ -400,17 +699,44 @@ func (r *mutationResolver) RegisterNewUser(ctx context.Context, userInput model.
 		UserPassword: hashedPassword,
 	}
 
-	err = newUser.Insert(ctx, database.DB, boil.Infer())
+	// insert the user and their default roles in a single transaction
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = newUser.Insert(ctx, tx, boil.Infer())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range constants.DEFAULT_USER_ROLES {
+		_, err = utils.GrantRole(ctx, tx, newUser.UserID, role)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	// send a link to confirm the email address belongs to the user
+	// the email is queued rather than sent during the request,
+	// and a failed email does not undo the registration, since the user can request another
+	err = utils.SendVerificationEmail(ctx, &newUser)
+	if err != nil {
+		middleware.StoreErrorLog(ctx, err)
+	}
+
 	// format user and remove password from struct
 	formattedUser := utils.ConvertUser(&newUser)
 
 	// add new user to session
-	session.Set("user", formattedUser.UserID)
+	middleware.SignInSession(session, formattedUser.UserID)
 	err = session.Save()
 	if err != nil {
 		return nil, err
@@ -419,13 +745,58 @@ func (r *mutationResolver) RegisterNewUser(ctx context.Context, userInput model.
 	return &formattedUser, err
 }
 
-func (r *mutationResolver) ToggleUserActiveStatus(ctx context.Context) (*model.User, error) {
-	// authenticate user
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+func (r *mutationResolver) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	userID, email, err := utils.ConsumeEmailVerificationToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// only verify the address the link was sent to
+	user, err := sql_models.Users(qm.Where("user_id = ?", userID), qm.Where("email = ?", email)).One(ctx, database.DB)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.Validation("token", constants.INVALID_VERIFICATION_TOKEN_ERROR_MESSAGE)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if !user.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = null.TimeFrom(time.Now())
+		_, err = user.Update(ctx, database.DB, boil.Whitelist(sql_models.UserColumns.EmailVerifiedAt))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	formattedUser := utils.ConvertUser(user)
+	return &formattedUser, nil
+}
+
+func (r *mutationResolver) ResendVerification(ctx context.Context) (bool, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if user.EmailVerifiedAt.Valid {
+		return false, apperrors.Validation("", constants.EMAIL_ALREADY_VERIFIED_ERROR_MESSAGE)
+	}
+
+	err = utils.SendVerificationEmail(ctx, user)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ToggleUserActiveStatus(ctx context.Context) (*model.User, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
 	// find current user status
 	user, err := sql_models.FindUser(ctx, database.DB, userID)
 	if err != nil {
@@ -443,7 +814,7 @@ func (r *mutationResolver) ToggleUserActiveStatus(ctx context.Context) (*model.U
 	return &fmtUser, nil
 }
 
-func (r *mutationResolver) Login(ctx context.Context, username string, password string) (*model.User, error) {
+func (r *mutationResolver) Login(ctx context.Context, username string, password string) (*model.LoginResult, error) {
 	// get session
 	_, session, err := middleware.GetGinContextAndSessions(ctx)
 	if err != nil {
@@ -458,21 +829,287 @@ func (r *mutationResolver) Login(ctx context.Context, username string, password
 	// compare password with hashed password
 	correctPassword := utils.CheckPasswordHash(password, user.UserPassword)
 	if !correctPassword {
-		return nil, errors.New(constants.INVALID_USERNAME_PASSWORD_ERROR_MESSAGE)
+		return nil, apperrors.Unauthenticated(constants.INVALID_USERNAME_PASSWORD_ERROR_MESSAGE)
+	}
+
+	// with two-factor authentication enabled, hold the user in the session
+	// until they complete the challenge with verifyTwoFactor
+	if user.TotpEnabledAt.Valid {
+		session.Delete("user")
+		session.Set("two_factor_user", user.UserID)
+		session.Set("two_factor_expires", time.Now().Add(constants.TWO_FACTOR_CHALLENGE_TTL).Unix())
+		err = session.Save()
+		if err != nil {
+			return nil, err
+		}
+		return &model.LoginResult{TwoFactorRequired: true}, nil
 	}
 
 	// access and save session
-	session.Set("user", user.UserID)
+	middleware.SignInSession(session, user.UserID)
 	err = session.Save()
 	if err != nil {
 		return nil, err
 	}
 
 	// format user object and return it
+	formattedUser := utils.ConvertUser(user)
+	return &model.LoginResult{User: &formattedUser}, nil
+}
+
+func (r *mutationResolver) VerifyTwoFactor(ctx context.Context, code string) (*model.User, error) {
+	_, session, err := middleware.GetGinContextAndSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// confirm the user entered their password recently
+	userID, ok := session.Get("two_factor_user").(int)
+	expires, _ := session.Get("two_factor_expires").(int64)
+	if !ok || time.Now().Unix() > expires {
+		return nil, apperrors.Unauthenticated(constants.TWO_FACTOR_CHALLENGE_EXPIRED_ERROR_MESSAGE)
+	}
+
+	// limit the codes each user may try, so they cannot be guessed
+	err = middleware.LimitAttempts(ctx, "two_factor:"+strconv.Itoa(userID), constants.TWO_FACTOR_ATTEMPT_LIMIT, constants.TWO_FACTOR_CHALLENGE_TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := utils.CheckTwoFactorCode(ctx, database.DB, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, apperrors.Validation("code", constants.INVALID_TWO_FACTOR_CODE_ERROR_MESSAGE)
+	}
+
+	// complete the sign in
+	session.Delete("two_factor_user")
+	session.Delete("two_factor_expires")
+	middleware.SignInSession(session, user.UserID)
+	err = session.Save()
+	if err != nil {
+		return nil, err
+	}
+
 	formattedUser := utils.ConvertUser(user)
 	return &formattedUser, nil
 }
 
+func (r *mutationResolver) EnableTwoFactor(ctx context.Context) (*model.TwoFactorEnrollment, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TotpEnabledAt.Valid {
+		return nil, apperrors.Validation("", constants.TWO_FACTOR_ALREADY_ENABLED_ERROR_MESSAGE)
+	}
+
+	// store the secret now, but only require codes once the user confirms
+	// their authenticator app works, replacing any unconfirmed secret
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encryptedSecret, err := utils.EncryptTOTPSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TotpSecret = null.StringFrom(encryptedSecret)
+	_, err = user.Update(ctx, database.DB, boil.Whitelist(sql_models.UserColumns.TotpSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(constants.TWO_FACTOR_ISSUER, user.Username, secret),
+	}, nil
+}
+
+func (r *mutationResolver) ConfirmTwoFactor(ctx context.Context, code string) ([]string, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	err := middleware.LimitAttempts(ctx, "two_factor:"+strconv.Itoa(userID), constants.TWO_FACTOR_ATTEMPT_LIMIT, constants.TWO_FACTOR_CHALLENGE_TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TotpEnabledAt.Valid {
+		return nil, apperrors.Validation("", constants.TWO_FACTOR_ALREADY_ENABLED_ERROR_MESSAGE)
+	}
+	if !user.TotpSecret.Valid {
+		return nil, apperrors.Validation("", constants.TWO_FACTOR_ENROLLMENT_NOT_STARTED_ERROR_MESSAGE)
+	}
+
+	secret, err := utils.DecryptTOTPSecret(user.TotpSecret.String)
+	if err != nil {
+		return nil, err
+	}
+	step, valid := utils.ValidateTOTPCode(secret, code, time.Now(), 0)
+	if !valid {
+		return nil, apperrors.Validation("code", constants.INVALID_TWO_FACTOR_CODE_ERROR_MESSAGE)
+	}
+
+	// enable two-factor authentication and create the recovery codes together
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	user.TotpEnabledAt = null.TimeFrom(time.Now())
+	user.TotpLastStep = null.Int64From(step)
+	_, err = user.Update(ctx, tx, boil.Whitelist(sql_models.UserColumns.TotpEnabledAt, sql_models.UserColumns.TotpLastStep))
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := utils.ReplaceRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// API tokens skip the second factor, so any created before it was enabled are revoked
+	err = utils.RevokeUserAPITokens(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	// sign out every other session, since they were signed in without the second factor
+	err = middleware.RevokeOtherSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (r *mutationResolver) DisableTwoFactor(ctx context.Context, password string) (bool, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// limit password attempts, so a signed in session cannot be used to guess the password
+	err := middleware.LimitAttempts(ctx, "two_factor:"+strconv.Itoa(userID), constants.TWO_FACTOR_ATTEMPT_LIMIT, constants.TWO_FACTOR_CHALLENGE_TTL)
+	if err != nil {
+		return false, err
+	}
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil {
+		return false, err
+	}
+	if !user.TotpSecret.Valid {
+		return false, apperrors.Validation("", constants.TWO_FACTOR_NOT_ENABLED_ERROR_MESSAGE)
+	}
+
+	// require the password again, so an unattended session cannot remove the second factor
+	if !utils.CheckPasswordHash(password, user.UserPassword) {
+		return false, apperrors.Validation("password", constants.INCORRECT_PASSWORD_ERROR_MESSAGE)
+	}
+
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	user.TotpSecret = null.String{}
+	user.TotpEnabledAt = null.Time{}
+	user.TotpLastStep = null.Int64{}
+	_, err = user.Update(ctx, tx, boil.Whitelist(sql_models.UserColumns.TotpSecret, sql_models.UserColumns.TotpEnabledAt, sql_models.UserColumns.TotpLastStep))
+	if err != nil {
+		return false, err
+	}
+
+	_, err = sql_models.RecoveryCodes(sql_models.RecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) CreateAPIToken(ctx context.Context, name string, scopes []model.APITokenScope, expiresInDays *int) (*model.NewAPIToken, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, apperrors.Validation("name", constants.API_TOKEN_NAME_INVALID_ERROR_MESSAGE)
+	}
+	if len(scopes) == 0 {
+		return nil, apperrors.Validation("scopes", constants.API_TOKEN_SCOPES_REQUIRED_ERROR_MESSAGE)
+	}
+
+	// tokens without an expiration last until they are revoked
+	var expiresIn *time.Duration
+	if expiresInDays != nil {
+		if *expiresInDays < 1 {
+			return nil, apperrors.Validation("expires_in_days", constants.API_TOKEN_EXPIRY_INVALID_ERROR_MESSAGE)
+		}
+		duration := time.Hour * 24 * time.Duration(*expiresInDays)
+		expiresIn = &duration
+	}
+
+	token, apiToken, err := utils.CreateAPIToken(ctx, database.DB, userID, name, scopes, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	// the full token is only shown this once
+	formattedAPIToken := utils.ConvertAPIToken(apiToken)
+	return &model.NewAPIToken{
+		Token:    token,
+		APIToken: &formattedAPIToken,
+	}, nil
+}
+
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, tokenID int) (bool, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	// users may only revoke their own tokens
+	revoked, err := sql_models.APITokens(
+		sql_models.APITokenWhere.TokenID.EQ(tokenID),
+		sql_models.APITokenWhere.UserID.EQ(userID),
+		sql_models.APITokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, database.DB, sql_models.M{sql_models.APITokenColumns.RevokedAt: time.Now()})
+	if err != nil {
+		return false, err
+	}
+	if revoked == 0 {
+		return false, apperrors.NotFound(constants.API_TOKEN_NOT_FOUND_ERROR_MESSAGE)
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
 	// get gin context
 	_, session, err := middleware.GetGinContextAndSessions(ctx)
@@ -480,8 +1117,11 @@ func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	// access and remove user from session
+	// access and remove user from session, along with any unfinished two-factor sign in
 	session.Delete("user")
+	session.Delete("signed_in_at")
+	session.Delete("two_factor_user")
+	session.Delete("two_factor_expires")
 	err = session.Save()
 	if err != nil {
 		return false, err
@@ -491,47 +1131,54 @@ func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
 }
 
 func (r *mutationResolver) ForgotPassword(ctx context.Context, username string) (bool, error) {
-	// confirm username / email correspond to user in DB
-	user, err := sql_models.Users(qm.Where("username = ?", username), qm.Or("email = ?", username)).One(ctx, database.DB)
-	if err != nil || user == nil {
+	// limit requests from each IP address and for each account,
+	// counting unknown accounts the same way so the limits do not reveal which exist
+	ip, _, err := middleware.GetClientFingerprint(ctx)
+	if err != nil {
 		return false, err
 	}
-
-	// generate redis key using uuid that contains userid
-	// which will be obtained through the url
-	// and the url link shared via email with the user
-	// so that only someone with access to the user email on record
-	// should receive the reset link
-
-	// generate unique redis key
-	resetKeyUUID, err := uuid.NewV4()
+	err = middleware.LimitAttempts(ctx, "password_reset:ip:"+ip, constants.PASSWORD_RESET_IP_LIMIT, constants.PASSWORD_RESET_LIMIT_WINDOW)
 	if err != nil {
 		return false, err
 	}
 
-	resetKey := resetKeyUUID.String()
-	// store user_id in redis using the unique key
-	// with a one hour expiration
-	_, err = database.RedisClient.Set(ctx, resetKey, user.UserID, time.Hour*1).Result()
+	// confirm username / email correspond to user in DB
+	// respond the same way when they do not, so the response does not reveal if an account exists
+	user, err := sql_models.Users(qm.Where("username = ?", username), qm.Or("email = ?", username)).One(ctx, database.DB)
+	if errors.Is(err, sql.ErrNoRows) {
+		user = nil
+	} else if err != nil {
+		return false, err
+	}
 
+	err = middleware.LimitAttempts(ctx, utils.PasswordResetLimitKey(user, username), constants.PASSWORD_RESET_ACCOUNT_LIMIT, constants.PASSWORD_RESET_LIMIT_WINDOW)
 	if err != nil {
 		return false, err
 	}
+	if user == nil {
+		return true, nil
+	}
+
+	// only send reset links to addresses the user has confirmed they own
+	if !user.EmailVerifiedAt.Valid {
+		return true, nil
+	}
 
-	// send email with custom password reset link to user's email
-	err = utils.SendPasswordResetEmail(user.Email, resetKey)
+	// the email is queued rather than sent during the request,
+	// so the response takes about as long for unknown accounts as for real ones
+	// a failure is only logged, since an error here would reveal that the account exists
+	err = utils.SendPasswordResetEmail(ctx, user)
 	if err != nil {
-		return false, err
+		middleware.StoreErrorLog(ctx, err)
 	}
 
-	// return true if successful
 	return true, nil
 }
 
 func (r *mutationResolver) AccessPasswordReset(ctx context.Context, resetKey string) (bool, error) {
-	// confirm the uuid-generated password-reset url is in our redis DB
-	// before presenting reset form to the user
-	_, err := database.RedisClient.Get(ctx, resetKey).Result()
+	// confirm the reset link is still valid before presenting the reset form to the user
+	// the token is only used up when the password is reset
+	err := utils.CheckPasswordResetToken(ctx, resetKey)
 	if err != nil {
 		return false, err
 	}
@@ -539,50 +1186,68 @@ func (r *mutationResolver) AccessPasswordReset(ctx context.Context, resetKey str
 	return true, nil
 }
 
-func (r *mutationResolver) ResetPassword(ctx context.Context, resetKey string, userID int, newPassword string) (*model.User, error) {
-	// confirm reset key is active in redis
-	user_id, err := database.RedisClient.Get(ctx, resetKey).Result()
+func (r *mutationResolver) ResetPassword(ctx context.Context, resetKey string, userID int, newPassword string) (*model.LoginResult, error) {
+	// validate the new password first, so a rejected password does not use up the reset link
+	err := utils.ValidatePassword(newPassword)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.WithField(err, "new_password")
 	}
 
-	err = utils.ValidatePassword(newPassword)
+	// use up the reset token, confirming it was sent to this user
+	user, err := utils.ConsumePasswordResetToken(ctx, resetKey, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// convert user_id to int
-	user_id_int, err := strconv.Atoi(user_id)
+	// hash new password
+	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
 		return nil, err
 	}
 
-	// locate user in database
-	user, err := sql_models.FindUser(ctx, database.DB, user_id_int)
-	if err != nil || user == nil {
+	// update user password
+	user.UserPassword = hashedPassword
+	_, err = user.Update(ctx, database.DB, boil.Whitelist(sql_models.UserColumns.UserPassword))
+	if err != nil {
 		return nil, err
 	}
 
-	// hash new password
-	hashedPassword, err := utils.HashPassword(newPassword)
-
+	// remove any other reset links now that the password has changed
+	err = utils.InvalidatePasswordResetTokens(ctx, user.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	// update user password
-	user.UserPassword = hashedPassword
-	_, err = user.Update(ctx, database.DB, boil.Infer())
+	// revoke API tokens and sign out every other session too,
+	// since a reset usually means the account was compromised
+	err = utils.RevokeUserAPITokens(ctx, database.DB, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = middleware.RevokeOtherSessions(ctx, user.UserID)
 	if err != nil {
 		return nil, err
 	}
 
 	// get gin context/ sessions and sign in user
+	// users with two-factor authentication enabled must still complete verifyTwoFactor,
+	// and no user data is returned until they do
 	_, session, err := middleware.GetGinContextAndSessions(ctx)
 	if err != nil {
 		return nil, err
 	}
-	session.Set("user", user_id_int)
+	if user.TotpEnabledAt.Valid {
+		session.Delete("user")
+		session.Set("two_factor_user", user.UserID)
+		session.Set("two_factor_expires", time.Now().Add(constants.TWO_FACTOR_CHALLENGE_TTL).Unix())
+		err = session.Save()
+		if err != nil {
+			return nil, err
+		}
+		return &model.LoginResult{TwoFactorRequired: true}, nil
+	}
+
+	middleware.SignInSession(session, user.UserID)
 	err = session.Save()
 	if err != nil {
 		return nil, err
@@ -592,7 +1257,7 @@ func (r *mutationResolver) ResetPassword(ctx context.Context, resetKey string, u
 	fmtUser := utils.ConvertUser(user)
 
 	// return graphql user object
-	return &fmtUser, nil
+	return &model.LoginResult{User: &fmtUser}, nil
 }
 
 /* -------------------------------------------------------------------------- */
@@ -609,8 +1274,23 @@ func (r *postResolver) URLEncodedTitle(ctx context.Context, obj *model.Post) (st
 	return encodedTitle, nil
 }
 
-func (r *postResolver) Comments(ctx context.Context, obj *model.Post) (*model.PaginatedComments, error) {
-	paginatedComments, err := dataloader.For(ctx).CommentByPostID.Load(obj.PostID)
+func (r *postResolver) MyVote(ctx context.Context, obj *model.Post) (*model.VoteValue, error) {
+	// signed out users have no vote to show
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, nil
+	}
+
+	voteValue, err := dataloader.For(ctx).MyVoteByPostID.Load(dataloader.VoteKey{UserID: userID, ID: obj.PostID})
+	return &voteValue, err
+}
+
+func (r *postResolver) HTML(ctx context.Context, obj *model.Post) (string, error) {
+	return utils.RenderCachedHTML(ctx, obj.Format, obj.PostText)
+}
+
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, limit int, sort model.CommentSort) (*model.PaginatedComments, error) {
+	paginatedComments, err := dataloader.For(ctx).CommentByPostID.Load(dataloader.NewCommentsKey(obj.PostID, limit, sort))
 	return &paginatedComments, err
 }
 
@@ -619,22 +1299,77 @@ func (r *postResolver) Votes(ctx context.Context, obj *model.Post) (*model.Votes
 	return &votes, err
 }
 
+func (r *postResolver) Tags(ctx context.Context, obj *model.Post) ([]string, error) {
+	return dataloader.For(ctx).TagsByPostID.Load(obj.PostID)
+}
+
+func (r *postResolver) Revisions(ctx context.Context, obj *model.Post, limit int, offset int) (*model.PaginatedPostRevisions, error) {
+	// revision history is only visible to users allowed to edit the post,
+	// the same check used by diffRevisions and restoreRevision
+	// so, like User.posts, the dataloader pattern is currently not necessary
+	_, err := middleware.RejectIfCannotEditPost(ctx, &sql_models.Post{PostID: obj.PostID, UserID: obj.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	// cap the maximum possible limit and return with one extra
+	// to check for remaining revisions
+	var limitPlusOne int
+	trueLimit := 20
+	if limit > trueLimit {
+		limitPlusOne = trueLimit + 1
+	} else {
+		limitPlusOne = limit + 1
+	}
+
+	// get revisions from newest to oldest
+	revisions, err := sql_models.PostRevisions(qm.Where("post_id = ?", obj.PostID), qm.OrderBy("created_at DESC, revision_id DESC"), qm.Limit(limitPlusOne), qm.Offset(offset)).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if there are more revisions and remove the final one
+	hasMore := len(revisions) == limitPlusOne
+	if hasMore {
+		revisions = revisions[:len(revisions)-1]
+	}
+
+	// format revisions for graphQL response
+	formattedRevisions := make([]*model.PostRevision, len(revisions))
+	for i, value := range revisions {
+		fmtRevision := utils.ConvertPostRevision(value)
+		formattedRevisions[i] = &fmtRevision
+	}
+
+	paginatedResponse := model.PaginatedPostRevisions{
+		Revisions: formattedRevisions,
+		More:      hasMore,
+	}
+
+	return &paginatedResponse, nil
+}
+
+func (r *postRevisionResolver) Editor(ctx context.Context, obj *model.PostRevision) (*model.User, error) {
+	user, err := dataloader.For(ctx).UserById.Load(obj.EditorID)
+	return &user, err
+}
+
 /* -------------------------------------------------------------------------- */
 /*                                  get posts                                 */
 /* -------------------------------------------------------------------------- */
 
 // get single post
 func (r *queryResolver) GetPost(ctx context.Context, postID int) (*model.Post, error) {
-	post, err := sql_models.Posts(qm.Where("post_id = ?", postID)).One(ctx, database.DB)
+	post, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID)).One(ctx, database.DB)
 
 	if post == nil {
 		return nil, err
 	}
 
 	// check if post is published
-	// if unpublished, confirm user is author and authenticated
-	if !post.Published {
-		err = middleware.RejectIfNotAuthor(ctx, post.UserID)
+	// if unpublished or scheduled for later, confirm user is allowed to edit it
+	if !post.Published || (post.PublishAt.Valid && post.PublishAt.Time.After(time.Now())) {
+		_, err = middleware.RejectIfCannotEditPost(ctx, post)
 		if err != nil {
 			return nil, err
 		}
@@ -666,26 +1401,58 @@ func (r *queryResolver) GetUserByUsername(ctx context.Context, username string)
 	return &formattedUser, nil
 }
 
-// these will always be published since they will correspond to public urls
-func (r *queryResolver) GetPostByUsernameAndTitle(ctx context.Context, username string, title string) (*model.Post, error) {
-	// the title variable will pull a url-encoded slug, which will need to be unencoded before searching the database
-	unencodedTitle, err := url.QueryUnescape(title)
+// these will always be published since they will correspond to public urls
+func (r *queryResolver) GetPostByUsernameAndTitle(ctx context.Context, username string, title string) (*model.Post, error) {
+	// the title variable will pull a url-encoded slug, which will need to be unencoded before searching the database
+	unencodedTitle, err := url.QueryUnescape(title)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := sql_models.Users(qm.Where("username = ? AND active = true", username)).One(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := user.Posts(qm.Where("title = ? AND "+utils.VisiblePostClause, unencodedTitle)).One(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	fmtPost := utils.ConvertPost(post)
+	return &fmtPost, err
+}
+
+// look up a published post by its slug, following the slug history for old links
+func (r *queryResolver) GetPostBySlug(ctx context.Context, username string, slug string) (*model.PostBySlug, error) {
+	user, err := sql_models.Users(qm.Where("username = ? AND active = true", username)).One(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
 
-	user, err := sql_models.Users(qm.Where("username = ? AND active = true", username)).One(ctx, database.DB)
+	// check the current slugs first, since they take precedence over old ones
+	post, err := user.Posts(qm.Where("slug = ? AND "+utils.VisiblePostClause, slug)).One(ctx, database.DB)
+	if err == nil {
+		fmtPost := utils.ConvertPost(post)
+		return &model.PostBySlug{Post: &fmtPost}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// fall back to the slug history and redirect to the canonical slug
+	history, err := sql_models.FindPostSlugHistory(ctx, database.DB, user.UserID, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	post, err := user.Posts(qm.Where("title = ? AND published = true", unencodedTitle)).One(ctx, database.DB)
+	post, err = user.Posts(qm.Where("post_id = ? AND "+utils.VisiblePostClause, history.PostID)).One(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
 
 	fmtPost := utils.ConvertPost(post)
-	return &fmtPost, err
+	return &model.PostBySlug{Post: &fmtPost, RedirectTo: &post.Slug}, nil
 }
 
 func (r *queryResolver) GetManyPosts(ctx context.Context, postSearch model.PostSearch, authorID int) (*model.PaginatedPosts, error) {
@@ -699,20 +1466,26 @@ func (r *queryResolver) GetManyPosts(ctx context.Context, postSearch model.PostS
 		limitPlusOne = postSearch.Limit + 1
 	}
 
-	// get posts from DB with optional search by title
-	var posts sql_models.PostSlice
-	if postSearch.Title == nil {
-		retrievedPosts, err := sql_models.Posts(qm.Where("user_id = ? AND published = true", authorID), qm.Limit(limitPlusOne), qm.Offset(postSearch.Offset)).All(ctx, database.DB)
-		if err != nil {
-			return nil, err
-		}
-		posts = retrievedPosts
-	} else {
-		retrievedPosts, err := sql_models.Posts(qm.Where("user_id = ? AND published = true", authorID), qm.Limit(limitPlusOne), qm.Offset(postSearch.Offset), qm.Where("Title ILIKE ?", "%"+*postSearch.Title+"%")).All(ctx, database.DB)
-		if err != nil {
-			return nil, err
-		}
-		posts = retrievedPosts
+	// get posts from DB with optional search by title and tags
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id = ? AND "+utils.VisiblePostClause, authorID),
+		qm.Limit(limitPlusOne),
+		qm.Offset(postSearch.Offset),
+	}
+
+	filterMods, err := utils.PostFilterQueryMods(&model.PostFilter{
+		Title:    postSearch.Title,
+		Tags:     postSearch.Tags,
+		MatchAll: postSearch.MatchAll,
+	})
+	if err != nil {
+		return nil, err
+	}
+	queryMods = append(queryMods, filterMods...)
+
+	posts, err := sql_models.Posts(queryMods...).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
 	}
 
 	// format posts for graphQL response
@@ -730,19 +1503,47 @@ func (r *queryResolver) GetManyPosts(ctx context.Context, postSearch model.PostS
 	return &paginatedResponse, nil
 }
 
-// get unpublished posts for the current user - not visible to others
-func (r *queryResolver) GetUnpublishedPosts(ctx context.Context, limit int, offset int) (*model.PaginatedPosts, error) {
-	// get userID from sessions
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+// get a page of an author's visible posts with optional search by title and tags
+func (r *queryResolver) GetPostsConnection(ctx context.Context, authorID int, filter *model.PostFilter, first int, after *string) (*model.PostConnection, error) {
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id = ? AND "+utils.VisiblePostClause, authorID),
+	}
+
+	filterMods, err := utils.PostFilterQueryMods(filter)
 	if err != nil {
 		return nil, err
 	}
+	queryMods = append(queryMods, filterMods...)
 
-	// reject if unauthenticated
-	if userID == 0 {
-		return nil, errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	return utils.FetchPostConnection(ctx, database.DB, queryMods, first, after)
+}
+
+// count the visible posts using each tag for a given author
+func (r *queryResolver) GetTagCloud(ctx context.Context, authorID int) ([]*model.TagCount, error) {
+	var tagCounts []*model.TagCount
+	err := queries.Raw(`SELECT t.tag_name AS tag, COUNT(*) AS post_count FROM tags t
+		INNER JOIN post_tags pt ON pt.tag_id = t.tag_id
+		INNER JOIN posts p ON p.post_id = pt.post_id
+		WHERE p.user_id = $1 AND `+utils.VisiblePostClauseFor("p")+`
+		GROUP BY t.tag_name
+		ORDER BY post_count DESC, t.tag_name`, authorID).Bind(ctx, database.DB, &tagCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// return an empty list rather than null when the author has no tags
+	if tagCounts == nil {
+		tagCounts = []*model.TagCount{}
 	}
 
+	return tagCounts, nil
+}
+
+// get unpublished and scheduled posts for the current user - not visible to others
+func (r *queryResolver) GetUnpublishedPosts(ctx context.Context, limit int, offset int) (*model.PaginatedPosts, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
 	// cap the maximum possible limit and return with one extra
 	// to check for remaining posts
 	var limitPlusOne int
@@ -755,7 +1556,7 @@ func (r *queryResolver) GetUnpublishedPosts(ctx context.Context, limit int, offs
 
 	// get unpublished posts for user
 	// ignore title parameter of postInput, as these should be a fairly small number
-	posts, err := sql_models.Posts(qm.Where("user_id = ? AND published = false", userID), qm.Limit(limitPlusOne), qm.Offset(offset)).All(ctx, database.DB)
+	posts, err := sql_models.Posts(qm.Where("user_id = ? AND (published = false OR publish_at > now()) AND deleted_at IS NULL", userID), qm.Limit(limitPlusOne), qm.Offset(offset)).All(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
@@ -775,6 +1576,18 @@ func (r *queryResolver) GetUnpublishedPosts(ctx context.Context, limit int, offs
 	return &paginatedResponse, nil
 }
 
+// get a page of unpublished and scheduled posts for the current user - not visible to others
+func (r *queryResolver) GetUnpublishedPostsConnection(ctx context.Context, first int, after *string) (*model.PostConnection, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id = ? AND (published = false OR publish_at > now()) AND deleted_at IS NULL", userID),
+	}
+
+	return utils.FetchPostConnection(ctx, database.DB, queryMods, first, after)
+}
+
 func (r *queryResolver) GetManyUsers(ctx context.Context, userSearch model.UserSearch) (*model.PaginatedUsers, error) {
 	// cap the maximum possible limit and return with one extra
 	// to check for remaining users
@@ -817,6 +1630,16 @@ func (r *queryResolver) GetManyUsers(ctx context.Context, userSearch model.UserS
 	return &paginatedResponse, nil
 }
 
+// get a page of users with optional search by username
+func (r *queryResolver) GetUsersConnection(ctx context.Context, username *string, first int, after *string) (*model.UserConnection, error) {
+	var queryMods []qm.QueryMod
+	if username != nil {
+		queryMods = append(queryMods, qm.Where("username ILIKE ?", "%"+*username+"%"))
+	}
+
+	return utils.FetchUserConnection(ctx, database.DB, queryMods, first, after)
+}
+
 /* -------------------------------------------------------------------------- */
 /*                              get many comments                             */
 /* -------------------------------------------------------------------------- */
@@ -835,15 +1658,23 @@ func (r *queryResolver) GetManyComments(ctx context.Context, commentSearch model
 	// get comments from DB
 	var whereClause string
 	if commentSearch.ParentType == model.ParentTypePost {
-		whereClause = "post_id = ?"
+		whereClause = "post_id = ? AND deleted_at IS NULL"
 	} else {
-		whereClause = "response_to_comment_id = ?"
+		whereClause = "response_to_comment_id = ? AND deleted_at IS NULL"
 	}
-	retrievedComments, err := sql_models.Comments(qm.Where(whereClause, commentSearch.ParentID), qm.Limit(commentSearch.Limit), qm.Offset(commentSearch.Offset)).All(ctx, database.DB)
+	// order by the requested sort, with ties broken by created_at and comment_id
+	// so that comments are never skipped or repeated between pages
+	retrievedComments, err := sql_models.Comments(qm.Where(whereClause, commentSearch.ParentID), qm.Where(utils.VisiblePostCommentsClause), qm.OrderBy(utils.CommentOrderBy(commentSearch.Sort, "comments")), qm.Limit(limitPlusOne), qm.Offset(commentSearch.Offset)).All(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
 
+	// check if there are more comments and remove the extra one
+	more := len(retrievedComments) == limitPlusOne
+	if more {
+		retrievedComments = retrievedComments[:len(retrievedComments)-1]
+	}
+
 	// find which comments have subcomments
 	var currentCommentIDList []int
 	for _, value := range retrievedComments {
@@ -852,7 +1683,7 @@ func (r *queryResolver) GetManyComments(ctx context.Context, commentSearch model
 
 	// format ids as string for SQL ANY() argument
 	queryParam := utils.FormatSliceForSQLParams(currentCommentIDList)
-	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[])", queryParam)).All(ctx, database.DB)
+	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[]) AND deleted_at IS NULL", queryParam)).All(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
@@ -873,28 +1704,400 @@ func (r *queryResolver) GetManyComments(ctx context.Context, commentSearch model
 
 	paginatedResponse := model.PaginatedComments{
 		Comments: formattedComments,
-		More:     len(retrievedComments) == limitPlusOne,
+		More:     more,
 	}
 
 	return &paginatedResponse, nil
 }
 
+// get a page of the comments on a post or replies to a comment
+func (r *queryResolver) GetCommentsConnection(ctx context.Context, parentID int, parentType model.ParentType, first int, after *string) (*model.CommentConnection, error) {
+	var whereClause string
+	if parentType == model.ParentTypePost {
+		whereClause = "post_id = ? AND deleted_at IS NULL"
+	} else {
+		whereClause = "response_to_comment_id = ? AND deleted_at IS NULL"
+	}
+
+	queryMods := []qm.QueryMod{qm.Where(whereClause, parentID), qm.Where(utils.VisiblePostCommentsClause)}
+	return utils.FetchCommentConnection(ctx, database.DB, queryMods, first, after)
+}
+
 /* -------------------------------------------------------------------------- */
-/*                          various utility functions    ß                     */
+/*                                post rankings                               */
 /* -------------------------------------------------------------------------- */
 
-func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
-	_, session, err := middleware.GetGinContextAndSessions(ctx)
+// rankings are read from the post_rankings table, which is recomputed by the ranking job
+func (r *queryResolver) GetTrendingPosts(ctx context.Context, window model.TrendingWindow, limit int, cursor *string) (*model.RankedPosts, error) {
+	// cap the maximum possible limit and return with one extra
+	// to check for remaining posts
+	// at least one post is returned, so the cursor always moves forward
+	var limitPlusOne int
+	trueLimit := 20
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > trueLimit {
+		limitPlusOne = trueLimit + 1
+	} else {
+		limitPlusOne = limit + 1
+	}
+
+	return utils.FetchRankedPosts(ctx, database.DB, utils.TrendingScoreColumns[window], limitPlusOne, cursor)
+}
+
+func (r *queryResolver) GetFrontPage(ctx context.Context, sort model.PostSort, limit int, cursor *string) (*model.RankedPosts, error) {
+	// cap the maximum possible limit and return with one extra
+	// to check for remaining posts
+	// at least one post is returned, so the cursor always moves forward
+	var limitPlusOne int
+	trueLimit := 20
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > trueLimit {
+		limitPlusOne = trueLimit + 1
+	} else {
+		limitPlusOne = limit + 1
+	}
+
+	// the newest posts are read directly from the posts table
+	if sort == model.PostSortNew {
+		return utils.FetchNewestPosts(ctx, database.DB, limitPlusOne, cursor)
+	}
+
+	return utils.FetchRankedPosts(ctx, database.DB, utils.FrontPageScoreColumns[sort], limitPlusOne, cursor)
+}
+
+func (r *queryResolver) GetLeaderboard(ctx context.Context, period model.LeaderboardPeriod, limit int) ([]*model.LeaderboardEntry, error) {
+	// cap the maximum possible limit
+	trueLimit := 100
+	if limit > trueLimit {
+		limit = trueLimit
+	}
+	if limit < 1 {
+		return []*model.LeaderboardEntry{}, nil
+	}
+
+	return utils.FetchLeaderboard(ctx, database.DB, period, limit)
+}
+
+func (r *queryResolver) GetFlaggedVotes(ctx context.Context, limit int, cursor *string) (*model.FlaggedVotes, error) {
+	// cap the maximum possible limit and return with one extra
+	// to check for remaining votes
+	// at least one vote is returned, so the cursor always moves forward
+	var limitPlusOne int
+	trueLimit := 50
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > trueLimit {
+		limitPlusOne = trueLimit + 1
+	} else {
+		limitPlusOne = limit + 1
+	}
+
+	return utils.FetchFlaggedVotes(ctx, database.DB, limitPlusOne, cursor)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                comment tree                                */
+/* -------------------------------------------------------------------------- */
+
+func (r *queryResolver) GetCommentTree(ctx context.Context, postID int, maxDepth int, perLevelLimit int, sort model.CommentSort, after *string) (*model.CommentTree, error) {
+	// cap the depth and number of replies per comment
+	// so a single request stays bounded
+	trueMaxDepth := 10
+	if maxDepth > trueMaxDepth {
+		maxDepth = trueMaxDepth
+	} else if maxDepth < 1 {
+		maxDepth = 1
+	}
+
+	trueLimit := 20
+	if perLevelLimit > trueLimit {
+		perLevelLimit = trueLimit
+	} else if perLevelLimit < 1 {
+		perLevelLimit = 1
+	}
+
+	return utils.FetchCommentTree(ctx, database.DB, postID, maxDepth, perLevelLimit, sort, after)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              full-text search                              */
+/* -------------------------------------------------------------------------- */
+
+func (r *queryResolver) Search(ctx context.Context, query string, types []model.SearchType, limit int, cursor *string) (*model.SearchResults, error) {
+	// cap the maximum possible limit and return with one extra
+	// to check for remaining results
+	// at least one result is returned, so the cursor always moves forward
+	var limitPlusOne int
+	trueLimit := 20
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > trueLimit {
+		limitPlusOne = trueLimit + 1
+	} else {
+		limitPlusOne = limit + 1
+	}
+
+	offset, err := utils.DecodeOffsetCursor(cursor)
+	if err != nil {
+		return nil, apperrors.WithField(err, "cursor")
+	}
+
+	emptyResults := model.SearchResults{Hits: []*model.SearchHit{}}
+
+	// convert user input into a tsquery, returning no results if nothing searchable remains
+	tsQuery := utils.BuildSearchQuery(query)
+	if tsQuery == "" {
+		return &emptyResults, nil
+	}
+
+	// search both posts and comments unless specific types are requested
+	searchPosts := len(types) == 0
+	searchComments := len(types) == 0
+	for _, searchType := range types {
+		if searchType == model.SearchTypePost {
+			searchPosts = true
+		}
+		if searchType == model.SearchTypeComment {
+			searchComments = true
+		}
+	}
+
+	// only published, non-deleted posts and non-deleted comments on them are searchable
+	var rankedQueries []string
+	if searchPosts {
+		rankedQueries = append(rankedQueries, `
+			SELECT 'post' AS result_type, p.post_id AS id, ts_rank(p.search_vector, q.query) AS rank
+			FROM posts p, q
+			WHERE p.search_vector @@ q.query AND `+utils.VisiblePostClauseFor("p"))
+	}
+	if searchComments {
+		rankedQueries = append(rankedQueries, `
+			SELECT 'comment' AS result_type, c.comment_id AS id, ts_rank(c.search_vector, q.query) AS rank
+			FROM comments c INNER JOIN posts p ON p.post_id = c.post_id, q
+			WHERE c.search_vector @@ q.query AND c.deleted_at IS NULL AND `+utils.VisiblePostClauseFor("p"))
+	}
+
+	// rank and paginate first, then build the more expensive headlines for the current page only
+	type searchRow struct {
+		ResultType string  `boil:"result_type"`
+		ID         int     `boil:"id"`
+		Rank       float64 `boil:"rank"`
+		Headline   string  `boil:"headline"`
+	}
+	var rows []searchRow
+	err = queries.Raw(`
+		WITH q AS (SELECT to_tsquery('english', $1) AS query),
+		hits AS (`+strings.Join(rankedQueries, " UNION ALL ")+`
+			ORDER BY rank DESC, result_type, id
+			LIMIT $2 OFFSET $3
+		)
+		SELECT hits.result_type, hits.id, hits.rank,
+			CASE WHEN hits.result_type = 'post'
+			THEN ts_headline('english', p.title || ' ' || p.subtitle || ' ' || p.post_text, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2')
+			ELSE ts_headline('english', c.comment_text, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2')
+			END AS headline
+		FROM hits CROSS JOIN q
+		LEFT JOIN posts p ON hits.result_type = 'post' AND p.post_id = hits.id
+		LEFT JOIN comments c ON hits.result_type = 'comment' AND c.comment_id = hits.id
+		ORDER BY hits.rank DESC, hits.result_type, hits.id`, tsQuery, limitPlusOne, offset).Bind(ctx, database.DB, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if there are more results and remove the final one
+	hasMore := len(rows) == limitPlusOne
+	if hasMore {
+		rows = rows[:len(rows)-1]
+	}
+
+	// fetch the matching posts and comments
+	var postIDs []int
+	var commentIDs []int
+	for _, row := range rows {
+		if row.ResultType == "post" {
+			postIDs = append(postIDs, row.ID)
+		} else {
+			commentIDs = append(commentIDs, row.ID)
+		}
+	}
+
+	posts, err := sql_models.Posts(qm.Where("post_id = ANY(?::int[])", utils.FormatSliceForSQLParams(postIDs))).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := sql_models.Comments(qm.Where("comment_id = ANY(?::int[])", utils.FormatSliceForSQLParams(commentIDs))).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+	subComments, err := sql_models.Comments(qm.Where("response_to_comment_id = ANY(?::int[]) AND deleted_at IS NULL", utils.FormatSliceForSQLParams(commentIDs))).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	// format hits for graphQL response in ranked order
+	hits := []*model.SearchHit{}
+	for _, row := range rows {
+		hit := model.SearchHit{
+			Rank:     row.Rank,
+			Headline: utils.SanitizeHeadline(row.Headline),
+		}
+		if row.ResultType == "post" {
+			hit.Type = model.SearchTypePost
+			for _, post := range posts {
+				if post.PostID == row.ID {
+					fmtPost := utils.ConvertPost(post)
+					hit.Post = &fmtPost
+				}
+			}
+		} else {
+			hit.Type = model.SearchTypeComment
+			for _, comment := range comments {
+				if comment.CommentID == row.ID {
+					// check if comment has subcomments
+					hasSubComment := false
+					for _, subComment := range subComments {
+						if subComment.ResponseToCommentID.Int == comment.CommentID {
+							hasSubComment = true
+						}
+					}
+					fmtComment := utils.ConvertComment(comment, hasSubComment)
+					hit.Comment = &fmtComment
+				}
+			}
+		}
+		hits = append(hits, &hit)
+	}
+
+	searchResults := model.SearchResults{
+		Hits: hits,
+		More: hasMore,
+	}
+	if hasMore {
+		nextCursor := utils.EncodeOffsetCursor(offset + len(rows))
+		searchResults.Cursor = &nextCursor
+	}
+
+	return &searchResults, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    trash                                   */
+/* -------------------------------------------------------------------------- */
+
+// get deleted posts and comments for the current user
+// these can be restored until the purge job removes them
+func (r *queryResolver) GetTrash(ctx context.Context) (*model.Trash, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	posts, err := sql_models.Posts(qm.Where("user_id = ? AND deleted_at IS NOT NULL", userID), qm.OrderBy("deleted_at DESC")).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := sql_models.Comments(qm.Where("user_id = ? AND deleted_at IS NOT NULL", userID), qm.OrderBy("deleted_at DESC")).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	// format posts and comments for graphQL response
+	formattedPosts := make([]*model.Post, len(posts))
+	for i, value := range posts {
+		fmtPost := utils.ConvertPost(value)
+		formattedPosts[i] = &fmtPost
+	}
+
+	// subcomments are not shown for comments in the trash
+	formattedComments := make([]*model.Comment, len(comments))
+	for i, value := range comments {
+		fmtComment := utils.ConvertComment(value, false)
+		formattedComments[i] = &fmtComment
+	}
+
+	trash := model.Trash{
+		Posts:         formattedPosts,
+		Comments:      formattedComments,
+		RetentionDays: constants.TRASH_RETENTION_DAYS,
+	}
+
+	return &trash, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               revision diffs                               */
+/* -------------------------------------------------------------------------- */
+
+// compare two revisions of a post line by line,
+// or a revision with the current post when no newer revision is given
+func (r *queryResolver) DiffRevisions(ctx context.Context, postID int, from int, to *int) (*model.RevisionDiff, error) {
+	post, err := sql_models.Posts(qm.Where("post_id = ? AND deleted_at IS NULL", postID)).One(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	// only users allowed to edit the post may view its revision history
+	_, err = middleware.RejectIfCannotEditPost(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := sql_models.PostRevisions(qm.Where("post_id = ? AND revision_id = ?", postID, from)).One(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	toTitle, toSubtitle, toPostText := post.Title, post.Subtitle, post.PostText
+	if to != nil {
+		toRevision, err := sql_models.PostRevisions(qm.Where("post_id = ? AND revision_id = ?", postID, *to)).One(ctx, database.DB)
+		if err != nil {
+			return nil, err
+		}
+		toTitle, toSubtitle, toPostText = toRevision.Title, toRevision.Subtitle, toRevision.PostText
+	}
+
+	titleDiff, err := utils.DiffLines(fromRevision.Title, toTitle)
+	if err != nil {
+		return nil, err
+	}
+	subtitleDiff, err := utils.DiffLines(fromRevision.Subtitle, toSubtitle)
+	if err != nil {
+		return nil, err
+	}
+	postTextDiff, err := utils.DiffLines(fromRevision.PostText, toPostText)
 	if err != nil {
 		return nil, err
 	}
 
-	user := session.Get("user")
-	if user == 0 || user == nil {
+	diff := model.RevisionDiff{
+		PostID:   postID,
+		From:     from,
+		To:       to,
+		Title:    titleDiff,
+		Subtitle: subtitleDiff,
+		PostText: postTextDiff,
+	}
+
+	return &diff, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          various utility functions    ß                     */
+/* -------------------------------------------------------------------------- */
+
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	// read the user from context, so requests using an API token are included
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
 		return nil, nil
 	}
 
-	u, err := sql_models.Users(qm.Where("user_id = ?", user)).One(ctx, database.DB)
+	u, err := sql_models.Users(qm.Where("user_id = ?", userID)).One(ctx, database.DB)
 	if err != nil {
 		//error log
 		return nil, err
@@ -904,13 +2107,65 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return &formattedUser, nil
 }
 
+func (r *queryResolver) MyAPITokens(ctx context.Context) ([]*model.APIToken, error) {
+	// signed out users are rejected by the @authenticated directive
+	userID := middleware.GetUserIDFromContext(ctx)
+
+	apiTokens, err := sql_models.APITokens(
+		sql_models.APITokenWhere.UserID.EQ(userID),
+		sql_models.APITokenWhere.RevokedAt.IsNull(),
+		qm.Where("(expires_at IS NULL OR expires_at > now())"),
+		qm.OrderBy("created_at DESC"),
+	).All(ctx, database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	formattedAPITokens := []*model.APIToken{}
+	for _, apiToken := range apiTokens {
+		formattedAPIToken := utils.ConvertAPIToken(apiToken)
+		formattedAPITokens = append(formattedAPITokens, &formattedAPIToken)
+	}
+	return formattedAPITokens, nil
+}
+
+func (r *queryResolver) MyRoles(ctx context.Context) ([]model.Role, error) {
+	// signed out users have no roles
+	userID := middleware.GetUserIDFromContext(ctx)
+	roleNames, err := middleware.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []model.Role{}
+	for _, roleName := range roleNames {
+		roles = append(roles, model.Role(roleName))
+	}
+	return roles, nil
+}
+
 func (r *queryResolver) IsAuthor(ctx context.Context, authorID int) (bool, error) {
-	userID, err := middleware.GetUserIDFromSessions(ctx)
+	// kept for older clients, answering with the same permission check as canEditPost
+	// for any post written by the author
+	userID := middleware.GetUserIDFromContext(ctx)
+	return middleware.CanEditPost(ctx, userID, &sql_models.Post{UserID: authorID})
+}
+
+func (r *queryResolver) CanEditPost(ctx context.Context, postID int) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return false, nil
+	}
+
+	post, err := sql_models.Posts(qm.Select("post_id", "user_id"), qm.Where("post_id = ?", postID)).One(ctx, database.DB)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
-	isAuthor := authorID == userID
-	return isAuthor, nil
+
+	return middleware.CanEditPost(ctx, userID, post)
 }
 
 func (r *userResolver) Posts(ctx context.Context, obj *model.User) (*model.PaginatedPosts, error) {
@@ -921,7 +2176,7 @@ func (r *userResolver) Posts(ctx context.Context, obj *model.User) (*model.Pagin
 	// pagination will limit these to 20 posts
 	// for fetching additional posts, the GetManyPosts resolver can then be used
 	// with the limit and offset set accordingly
-	posts, err := sql_models.Posts(qm.Where("user_id = ? AND published = true", obj.UserID), qm.Limit(21)).All(ctx, database.DB)
+	posts, err := sql_models.Posts(qm.Where("user_id = ? AND "+utils.VisiblePostClause, obj.UserID), qm.Limit(21)).All(ctx, database.DB)
 	if err != nil {
 		return nil, err
 	}
@@ -949,20 +2204,36 @@ func (r *userResolver) Posts(ctx context.Context, obj *model.User) (*model.Pagin
 	return &response, nil
 }
 
-func (r *userResolver) Comments(ctx context.Context, obj *model.User) (*model.PaginatedComments, error) {
-	paginatedComments, err := dataloader.For(ctx).CommentByUserID.Load(obj.UserID)
+func (r *userResolver) PostsConnection(ctx context.Context, obj *model.User, first int, after *string) (*model.PostConnection, error) {
+	// as with posts, this should only be called for the blog author
+	// so the dataloader pattern is currently not necessary
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id = ? AND "+utils.VisiblePostClause, obj.UserID),
+	}
+
+	return utils.FetchPostConnection(ctx, database.DB, queryMods, first, after)
+}
+
+func (r *userResolver) Comments(ctx context.Context, obj *model.User, limit int) (*model.PaginatedComments, error) {
+	paginatedComments, err := dataloader.For(ctx).CommentByUserID.Load(dataloader.NewCommentsKey(obj.UserID, limit, model.CommentSortNew))
 	return &paginatedComments, err
 }
 
 // Comment returns generated.CommentResolver implementation.
 func (r *Resolver) Comment() generated.CommentResolver { return &commentResolver{r} }
 
+// FlaggedVote returns generated.FlaggedVoteResolver implementation.
+func (r *Resolver) FlaggedVote() generated.FlaggedVoteResolver { return &flaggedVoteResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 // Post returns generated.PostResolver implementation.
 func (r *Resolver) Post() generated.PostResolver { return &postResolver{r} }
 
+// PostRevision returns generated.PostRevisionResolver implementation.
+func (r *Resolver) PostRevision() generated.PostRevisionResolver { return &postRevisionResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
@@ -970,7 +2241,9 @@ func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
 
 type commentResolver struct{ *Resolver }
+type flaggedVoteResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
+type postRevisionResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
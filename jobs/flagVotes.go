@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	"github.com/jt-rose/clean_blog_server/utils"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// a vote matching one of the manipulation patterns
+type suspiciousVote struct {
+	Target   string `boil:"target"`
+	TargetID int    `boil:"target_id"`
+	UserID   int    `boil:"user_id"`
+	Reason   string `boil:"reason"`
+}
+
+// find recent post and comment votes matching a manipulation pattern
+// votes that are neutral or already flagged are skipped, and a vote reinstated by an admin
+// is not flagged again for the same pattern unless it has been changed since
+// when a vote matches several patterns, the first reason listed is kept
+const suspiciousVotesSQL = `
+WITH recent_votes AS (
+	SELECT 'post' AS target, post_id AS target_id, user_id, vote_value, updated_at, ip_address, fingerprint, reinstated_at, reinstated_reason
+	FROM post_votes
+	WHERE updated_at > $1 AND vote_value <> 0 AND flagged_at IS NULL
+	UNION ALL
+	SELECT 'comment', comment_id, user_id, vote_value, updated_at, ip_address, fingerprint, reinstated_at, reinstated_reason
+	FROM comment_votes
+	WHERE updated_at > $1 AND vote_value <> 0 AND flagged_at IS NULL
+),
+-- votes cast by new accounts with many other votes close by
+bursts AS (
+	SELECT v.target, v.target_id, v.user_id, 'new_account_burst' AS reason, 1 AS priority
+	FROM recent_votes v
+	INNER JOIN users u ON u.user_id = v.user_id
+	WHERE v.updated_at < u.created_at + make_interval(secs => $2)
+	AND (
+		SELECT COUNT(*) FROM recent_votes b
+		WHERE b.user_id = v.user_id
+		AND b.updated_at BETWEEN v.updated_at - make_interval(secs => $3) AND v.updated_at + make_interval(secs => $3)
+	) >= $4
+),
+-- pairs of users whose votes almost always match
+pairs AS (
+	SELECT a.user_id AS user_a, b.user_id AS user_b, COUNT(*) AS shared
+	FROM recent_votes a
+	INNER JOIN recent_votes b ON b.target = a.target AND b.target_id = a.target_id
+		AND b.vote_value = a.vote_value AND b.user_id > a.user_id
+	GROUP BY a.user_id, b.user_id
+	HAVING COUNT(*) >= $5
+),
+vote_totals AS (
+	SELECT user_id, COUNT(*) AS total FROM recent_votes GROUP BY user_id
+),
+rings AS (
+	SELECT v.target, v.target_id, v.user_id, 'voting_ring' AS reason, 2 AS priority
+	FROM pairs p
+	INNER JOIN vote_totals ta ON ta.user_id = p.user_a
+	INNER JOIN vote_totals tb ON tb.user_id = p.user_b
+	INNER JOIN recent_votes v ON v.user_id IN (p.user_a, p.user_b)
+	WHERE p.shared >= $6::DOUBLE PRECISION * GREATEST(ta.total, tb.total)
+	AND EXISTS (
+		SELECT 1 FROM recent_votes o
+		WHERE o.target = v.target AND o.target_id = v.target_id AND o.vote_value = v.vote_value
+		AND o.user_id = CASE WHEN v.user_id = p.user_a THEN p.user_b ELSE p.user_a END
+	)
+),
+-- matching votes on the same target from one address or client, keeping the earliest
+shared_ips AS (
+	SELECT target, target_id, user_id, 'shared_ip' AS reason, 3 AS priority FROM (
+		SELECT target, target_id, user_id,
+			ROW_NUMBER() OVER (PARTITION BY target, target_id, vote_value, ip_address ORDER BY updated_at, user_id) AS position
+		FROM recent_votes WHERE ip_address IS NOT NULL
+	) v WHERE position > 1
+),
+shared_fingerprints AS (
+	SELECT target, target_id, user_id, 'shared_fingerprint' AS reason, 4 AS priority FROM (
+		SELECT target, target_id, user_id,
+			ROW_NUMBER() OVER (PARTITION BY target, target_id, vote_value, fingerprint ORDER BY updated_at, user_id) AS position
+		FROM recent_votes WHERE fingerprint IS NOT NULL
+	) v WHERE position > 1
+)
+SELECT DISTINCT ON (s.target, s.target_id, s.user_id) s.target, s.target_id, s.user_id, s.reason
+FROM (
+	SELECT * FROM bursts
+	UNION ALL SELECT * FROM rings
+	UNION ALL SELECT * FROM shared_ips
+	UNION ALL SELECT * FROM shared_fingerprints
+) s
+INNER JOIN recent_votes v ON v.target = s.target AND v.target_id = s.target_id AND v.user_id = s.user_id
+WHERE v.reinstated_reason IS DISTINCT FROM s.reason OR v.updated_at > v.reinstated_at
+ORDER BY s.target, s.target_id, s.user_id, s.priority`
+
+// FlagSuspiciousVotes checks recent votes for sock-puppet patterns and flags the matches,
+// leaving them out of the vote totals until an admin reinstates them
+// a transaction-level advisory lock ensures only one server instance analyzes votes at a time
+func FlagSuspiciousVotes(ctx context.Context) (int, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// skip this run if another instance is already analyzing votes
+	var acquired bool
+	err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('flag_suspicious_votes'))`).Scan(&acquired)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	var votes []suspiciousVote
+	err = queries.Raw(suspiciousVotesSQL,
+		time.Now().Add(-constants.VOTE_ANALYSIS_LOOKBACK),
+		constants.VOTE_NEW_ACCOUNT_AGE.Seconds(),
+		constants.VOTE_BURST_WINDOW.Seconds(),
+		constants.VOTE_BURST_SIZE,
+		constants.VOTE_RING_MIN_SHARED,
+		constants.VOTE_RING_MIN_AGREEMENT,
+	).Bind(ctx, tx, &votes)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	// flag each vote, updating the counters and reputation it contributed to
+	flagged := 0
+	for _, vote := range votes {
+		ok, err := utils.FlagVote(ctx, tx, gql_models.ParentType(vote.Target), vote.TargetID, vote.UserID, gql_models.VoteFlagReason(vote.Reason))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			flagged++
+		}
+	}
+
+	// committing releases the advisory lock
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return flagged, nil
+}
+
+// StartVoteAnalyzer runs FlagSuspiciousVotes in the background on a fixed interval
+func StartVoteAnalyzer(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			_, err := FlagSuspiciousVotes(context.Background())
+			if err != nil {
+				fmt.Println("Vote analysis failed: ", err.Error())
+			}
+		}
+	}()
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "github.com/jt-rose/clean_blog_server/database"
+)
+
+// PublishScheduledPosts flips scheduled posts to published once their publish_at time has passed
+// a transaction-level advisory lock ensures only one server instance runs the update at a time
+func PublishScheduledPosts(ctx context.Context) (int64, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// skip this run if another instance is already publishing
+	var acquired bool
+	err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('publish_scheduled_posts'))`).Scan(&acquired)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	// the update only matches unpublished posts, so repeated runs are harmless
+	result, err := tx.ExecContext(ctx, `
+		UPDATE posts SET published = true
+		WHERE published = false AND publish_at <= now() AND deleted_at IS NULL`)
+	if err != nil {
+		return 0, err
+	}
+	published, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// committing releases the advisory lock
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+// StartScheduledPublisher runs PublishScheduledPosts in the background on a fixed interval
+func StartScheduledPublisher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			published, err := PublishScheduledPosts(context.Background())
+			if err != nil {
+				fmt.Println("Scheduled publishing failed: ", err.Error())
+				continue
+			}
+			if published > 0 {
+				fmt.Printf("Published %d scheduled posts\n", published)
+			}
+		}
+	}()
+}
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+)
+
+// select every comment that should be removed alongside the expired trash:
+// comments deleted before the cutoff, comments on posts deleted before the cutoff,
+// and every subcomment nested beneath either of them
+const expiredCommentsCTE = `
+WITH RECURSIVE expired_comments AS (
+	SELECT comment_id FROM comments
+	WHERE deleted_at < $1
+	OR post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)
+	UNION
+	SELECT c.comment_id FROM comments c
+	INNER JOIN expired_comments e ON c.response_to_comment_id = e.comment_id
+)`
+
+// PurgeTrash permanently deletes posts and comments that have been in the trash
+// longer than the retention period, along with their votes, revisions, tags, slug history, rankings, and subcomments
+func PurgeTrash(ctx context.Context, cutoff time.Time) (int64, int64, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	// remove comment votes and comments first to satisfy the foreign keys
+	_, err = tx.ExecContext(ctx, expiredCommentsCTE+`
+		DELETE FROM comment_votes WHERE comment_id IN (SELECT comment_id FROM expired_comments)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, expiredCommentsCTE+`
+		DELETE FROM comments WHERE comment_id IN (SELECT comment_id FROM expired_comments)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+	purgedComments, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// then remove post votes, revision history, and the posts themselves
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM post_votes WHERE post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM post_revisions WHERE post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM post_tags WHERE post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM post_slug_history WHERE post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM post_rankings WHERE post_id IN (SELECT post_id FROM posts WHERE deleted_at < $1)`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	result, err = tx.ExecContext(ctx, `DELETE FROM posts WHERE deleted_at < $1`, cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+	purgedPosts, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return purgedPosts, purgedComments, nil
+}
+
+// StartTrashPurge runs PurgeTrash in the background on a fixed interval
+// deletes are idempotent, so it is safe for several server instances to run it
+func StartTrashPurge(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			cutoff := time.Now().Add(-constants.TRASH_RETENTION_PERIOD)
+			purgedPosts, purgedComments, err := PurgeTrash(context.Background(), cutoff)
+			if err != nil {
+				fmt.Println("Trash purge failed: ", err.Error())
+				continue
+			}
+			if purgedPosts > 0 || purgedComments > 0 {
+				fmt.Printf("Trash purge removed %d posts and %d comments\n", purgedPosts, purgedComments)
+			}
+		}
+	}()
+}
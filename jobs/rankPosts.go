@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+	"github.com/jt-rose/clean_blog_server/utils"
+)
+
+// trending score for a window: votes cast or changed plus comments made inside the window,
+// divided by the age of the post in hours raised to the gravity setting
+// posts with no new votes or comments that were created before the window are left out
+const trendingScoreSQL = `CASE WHEN p.created_at > now() - interval '%[1]s' OR COALESCE(c.%[2]s, 0) > 0 OR COALESCE(v.%[2]s_votes, 0) > 0
+	THEN (COALESCE(v.%[2]s, 0) + $1 * COALESCE(c.%[2]s, 0))
+		/ POWER(EXTRACT(EPOCH FROM now() - p.created_at) / 3600 + 2, $2)
+	END`
+
+// RankPosts recomputes the hot, top, and trending scores for every visible post by an active author
+// posts that are no longer visible are removed from the rankings
+// a transaction-level advisory lock ensures only one server instance ranks posts at a time
+func RankPosts(ctx context.Context) (int64, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// skip this run if another instance is already ranking posts
+	var acquired bool
+	err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('rank_posts'))`).Scan(&acquired)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO post_rankings (post_id, hot_score, top_score, trending_day, trending_week, trending_month, computed_at)
+		SELECT p.post_id,
+			hot_score(p.upvotes + $1 * COALESCE(c.total, 0), p.downvotes, p.created_at),
+			p.upvotes - p.downvotes + $1 * COALESCE(c.total, 0),
+			`+fmt.Sprintf(trendingScoreSQL, "1 day", "day")+`,
+			`+fmt.Sprintf(trendingScoreSQL, "7 days", "week")+`,
+			`+fmt.Sprintf(trendingScoreSQL, "30 days", "month")+`,
+			now()
+		FROM posts p
+		INNER JOIN users u ON u.user_id = p.user_id
+		LEFT JOIN (
+			SELECT post_id,
+				COUNT(*) AS total,
+				COUNT(*) FILTER (WHERE created_at > now() - interval '1 day') AS day,
+				COUNT(*) FILTER (WHERE created_at > now() - interval '7 days') AS week,
+				COUNT(*) FILTER (WHERE created_at > now() - interval '30 days') AS month
+			FROM comments WHERE deleted_at IS NULL GROUP BY post_id
+		) c ON c.post_id = p.post_id
+		LEFT JOIN (
+			SELECT post_id,
+				SUM(vote_value) FILTER (WHERE updated_at > now() - interval '1 day') AS day,
+				COUNT(*) FILTER (WHERE updated_at > now() - interval '1 day') AS day_votes,
+				SUM(vote_value) FILTER (WHERE updated_at > now() - interval '7 days') AS week,
+				COUNT(*) FILTER (WHERE updated_at > now() - interval '7 days') AS week_votes,
+				SUM(vote_value) FILTER (WHERE updated_at > now() - interval '30 days') AS month,
+				COUNT(*) FILTER (WHERE updated_at > now() - interval '30 days') AS month_votes
+			FROM post_votes WHERE vote_value != 0 AND flagged_at IS NULL GROUP BY post_id
+		) v ON v.post_id = p.post_id
+		WHERE u.active = true AND `+utils.VisiblePostClauseFor("p")+`
+		ON CONFLICT (post_id) DO UPDATE SET
+			hot_score = EXCLUDED.hot_score,
+			top_score = EXCLUDED.top_score,
+			trending_day = EXCLUDED.trending_day,
+			trending_week = EXCLUDED.trending_week,
+			trending_month = EXCLUDED.trending_month,
+			computed_at = EXCLUDED.computed_at`, constants.RANKING_COMMENT_WEIGHT, constants.TRENDING_GRAVITY)
+	if err != nil {
+		return 0, err
+	}
+	ranked, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// now() is fixed for the transaction, so any row not updated above
+	// belongs to a post that has been deleted, unpublished, or deactivated
+	_, err = tx.ExecContext(ctx, `DELETE FROM post_rankings WHERE computed_at < now()`)
+	if err != nil {
+		return 0, err
+	}
+
+	// committing releases the advisory lock
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return ranked, nil
+}
+
+// StartPostRanker runs RankPosts in the background on a fixed interval
+func StartPostRanker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			_, err := RankPosts(context.Background())
+			if err != nil {
+				fmt.Println("Post ranking failed: ", err.Error())
+			}
+		}
+	}()
+}
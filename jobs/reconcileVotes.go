@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	database "github.com/jt-rose/clean_blog_server/database"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// VoteCountDrift is a post or comment whose stored vote counters
+// do not match the votes recorded for it
+type VoteCountDrift struct {
+	Table           string `boil:"table_name"`
+	ID              int    `boil:"id"`
+	StoredUpvotes   int    `boil:"stored_upvotes"`
+	StoredDownvotes int    `boil:"stored_downvotes"`
+	ActualUpvotes   int    `boil:"actual_upvotes"`
+	ActualDownvotes int    `boil:"actual_downvotes"`
+}
+
+// recompute the counters for every post and comment from the vote tables,
+// leaving out flagged votes, and select the rows where they differ from the stored counters
+const voteCountDriftSQL = `
+	SELECT 'posts' AS table_name, p.post_id AS id,
+		p.upvotes AS stored_upvotes, p.downvotes AS stored_downvotes,
+		COALESCE(v.upvotes, 0) AS actual_upvotes, COALESCE(v.downvotes, 0) AS actual_downvotes
+	FROM posts p
+	LEFT JOIN (
+		SELECT post_id,
+			COUNT(*) FILTER (WHERE vote_value = 1) AS upvotes,
+			COUNT(*) FILTER (WHERE vote_value = -1) AS downvotes
+		FROM post_votes WHERE flagged_at IS NULL GROUP BY post_id
+	) v ON v.post_id = p.post_id
+	WHERE p.upvotes <> COALESCE(v.upvotes, 0) OR p.downvotes <> COALESCE(v.downvotes, 0)
+	UNION ALL
+	SELECT 'comments', c.comment_id,
+		c.upvotes, c.downvotes,
+		COALESCE(v.upvotes, 0), COALESCE(v.downvotes, 0)
+	FROM comments c
+	LEFT JOIN (
+		SELECT comment_id,
+			COUNT(*) FILTER (WHERE vote_value = 1) AS upvotes,
+			COUNT(*) FILTER (WHERE vote_value = -1) AS downvotes
+		FROM comment_votes WHERE flagged_at IS NULL GROUP BY comment_id
+	) v ON v.comment_id = c.comment_id
+	WHERE c.upvotes <> COALESCE(v.upvotes, 0) OR c.downvotes <> COALESCE(v.downvotes, 0)
+	ORDER BY 1, 2`
+
+// ReconcileVoteCounts compares the upvote and downvote counters on posts and comments
+// against the vote tables and returns every row that has drifted
+// when fix is true the drifted counters are overwritten with the recomputed values
+func ReconcileVoteCounts(ctx context.Context, fix bool) ([]VoteCountDrift, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// lock the counters so no votes change them between checking and fixing
+	if fix {
+		_, err = tx.ExecContext(ctx, `LOCK TABLE posts, comments IN SHARE ROW EXCLUSIVE MODE`)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var drift []VoteCountDrift
+	err = queries.Raw(voteCountDriftSQL).Bind(ctx, tx, &drift)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if !fix || len(drift) == 0 {
+		return drift, nil
+	}
+
+	for _, row := range drift {
+		if row.Table == "posts" {
+			_, err = tx.ExecContext(ctx, `UPDATE posts SET upvotes = $1, downvotes = $2 WHERE post_id = $3`, row.ActualUpvotes, row.ActualDownvotes, row.ID)
+		} else {
+			_, err = tx.ExecContext(ctx, `UPDATE comments SET upvotes = $1, downvotes = $2 WHERE comment_id = $3`, row.ActualUpvotes, row.ActualDownvotes, row.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return drift, nil
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// MaildirMailer saves messages to a local maildir instead of sending them,
+// so emails can be read with any mail client during development
+type MaildirMailer struct {
+	Dir string
+}
+
+// counts deliveries so file names stay unique within the same second
+var maildirDeliveries uint64
+
+func NewMaildirMailer(dir string) (*MaildirMailer, error) {
+	for _, subdir := range []string{"tmp", "new", "cur"} {
+		err := os.MkdirAll(filepath.Join(dir, subdir), 0700)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &MaildirMailer{Dir: dir}, nil
+}
+
+func (m *MaildirMailer) Send(ctx context.Context, msg *Message) error {
+	raw, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	name := fmt.Sprintf("%d.%d_%d.%s", time.Now().Unix(), os.Getpid(), atomic.AddUint64(&maildirDeliveries, 1), hostname)
+
+	// write to tmp first and move into new, so readers never see a partial message
+	tmpPath := filepath.Join(m.Dir, "tmp", name)
+	err = os.WriteFile(tmpPath, raw, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(m.Dir, "new", name))
+}
@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+)
+
+// Message is an email with a plain text body and an optional html body
+// From and To are bare addresses, such as "blog@example.com"
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer delivers messages through a transport such as SMTP or a local maildir
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+var ErrNoRecipients = errors.New("mailer: message has no recipients")
+var ErrQueueFull = errors.New("mailer: queue is full")
+var ErrQueueClosed = errors.New("mailer: queue is closed")
@@ -0,0 +1,119 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Bytes formats the message as MIME, with a multipart/alternative body
+// when it has both text and html versions
+func (msg *Message) Bytes() ([]byte, error) {
+	if len(msg.To) == 0 {
+		return nil, ErrNoRecipients
+	}
+
+	// reject line breaks, which would let a value add its own headers
+	for _, value := range append([]string{msg.From, msg.Subject}, msg.To...) {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, errors.New("mailer: header values cannot contain line breaks")
+		}
+	}
+
+	to := []string{}
+	for _, address := range msg.To {
+		to = append(to, (&mail.Address{Address: address}).String())
+	}
+
+	messageID, err := newMessageID(msg.From)
+	if err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	writeHeader(&message, "From", (&mail.Address{Address: msg.From}).String())
+	writeHeader(&message, "To", strings.Join(to, ", "))
+	writeHeader(&message, "Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader(&message, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&message, "Message-ID", messageID)
+	writeHeader(&message, "MIME-Version", "1.0")
+
+	// a text only message needs no multipart wrapper
+	if msg.HTML == "" {
+		writeHeader(&message, "Content-Type", "text/plain; charset=utf-8")
+		writeHeader(&message, "Content-Transfer-Encoding", "quoted-printable")
+		message.WriteString("\r\n")
+		err = writeQuotedPrintable(&message, msg.Text)
+		return message.Bytes(), err
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	writeHeader(&message, "Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()}))
+	message.WriteString("\r\n")
+
+	// clients show the last part they support, so the html version goes last
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = writeQuotedPrintable(writer, part.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = parts.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, name string, value string) {
+	buf.WriteString(name + ": " + value + "\r\n")
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, content string) error {
+	writer := quotedprintable.NewWriter(w)
+	_, err := writer.Write([]byte(content))
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// create a unique Message-ID using the domain of the sender's address
+func newMessageID(from string) (string, error) {
+	randomBytes := make([]byte, 16)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at != -1 {
+		domain = from[at+1:]
+	}
+	return "<" + hex.EncodeToString(randomBytes) + "@" + domain + ">", nil
+}
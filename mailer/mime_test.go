@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBytesRejectsLineBreaksInHeaders(t *testing.T) {
+	injected := "reader@example.com\r\nBcc: everyone@example.com"
+
+	messages := map[string]*Message{
+		"from":    {From: injected, To: []string{"reader@example.com"}, Subject: "Hello", Text: "Hello"},
+		"to":      {From: "blog@example.com", To: []string{injected}, Subject: "Hello", Text: "Hello"},
+		"subject": {From: "blog@example.com", To: []string{"reader@example.com"}, Subject: "Hello\nBcc: everyone@example.com", Text: "Hello"},
+	}
+	for name, msg := range messages {
+		_, err := msg.Bytes()
+		if err == nil {
+			t.Errorf("%s: line break was accepted", name)
+		}
+	}
+}
+
+func TestBytesFormatsHeaders(t *testing.T) {
+	msg := &Message{From: "blog@example.com", To: []string{"reader@example.com"}, Subject: "Hello", Text: "Hello", HTML: "<p>Hello</p>"}
+	formatted, err := msg.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, header := range []string{"From: <blog@example.com>\r\n", "To: <reader@example.com>\r\n", "Subject: Hello\r\n"} {
+		if !strings.Contains(string(formatted), header) {
+			t.Errorf("missing header %q", header)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TransportConfig holds the settings NewTransport needs,
+// which the server reads from the environment
+type TransportConfig struct {
+	Transport string // smtp, maildir, or memory
+	SMTPHost  string
+	SMTPPort  string
+	TLSMode   TLSMode
+	Username  string
+	Password  string
+	Maildir   string // where the maildir transport saves emails
+}
+
+// NewTransport creates the Mailer named by config.Transport
+func NewTransport(config TransportConfig) (Mailer, error) {
+	switch config.Transport {
+	case "smtp":
+		port, err := strconv.Atoi(config.SMTPPort)
+		if err != nil {
+			return nil, fmt.Errorf("mailer: invalid SMTP port %q", config.SMTPPort)
+		}
+		return NewSMTPMailer(config.SMTPHost, port, config.TLSMode, config.Username, config.Password)
+	case "maildir":
+		return NewMaildirMailer(config.Maildir)
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("mailer: unknown transport %q", config.Transport)
+	}
+}
+
+// Outbox sends the site's emails in the background once StartOutbox has been called
+var Outbox *Queue
+
+// StartOutbox sets up Outbox to send through the transport, with the same settings as NewQueue
+// the returned queue should be closed on shutdown, so emails that are still queued are delivered
+func StartOutbox(transport Mailer, workers int, size int, maxAttempts int, retryDelay time.Duration) *Queue {
+	Outbox = NewQueue(transport, workers, size, maxAttempts, retryDelay)
+	return Outbox
+}
@@ -0,0 +1,136 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Queue sends messages in the background through another Mailer,
+// retrying failed deliveries with an increasing delay
+type Queue struct {
+	transport   Mailer
+	messages    chan *queuedMessage
+	maxAttempts int
+	retryDelay  time.Duration
+	workers     sync.WaitGroup
+
+	// messages waiting to be retried are held by a timer rather than a worker,
+	// so one failing address does not hold up the rest of the queue
+	mu      sync.Mutex
+	closed  bool
+	retries map[*queuedMessage]*time.Timer
+	pending sync.WaitGroup
+}
+
+// a message and the number of failed attempts to deliver it so far
+type queuedMessage struct {
+	msg      *Message
+	failures int
+}
+
+// NewQueue starts workers that send queued messages through the transport
+// a message is dropped after maxAttempts failed deliveries, waiting retryDelay
+// after the first failure and doubling the wait after each one after that
+func NewQueue(transport Mailer, workers int, size int, maxAttempts int, retryDelay time.Duration) *Queue {
+	q := &Queue{
+		transport:   transport,
+		messages:    make(chan *queuedMessage, size),
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+		retries:     make(map[*queuedMessage]*time.Timer),
+	}
+
+	for i := 0; i < workers; i++ {
+		q.workers.Add(1)
+		go func() {
+			defer q.workers.Done()
+			for queued := range q.messages {
+				q.deliver(queued)
+			}
+		}()
+	}
+
+	return q
+}
+
+// Send checks the message can be built and adds it to the queue without waiting for delivery
+// returns ErrQueueFull rather than blocking the request when the queue has no room
+func (q *Queue) Send(ctx context.Context, msg *Message) error {
+	_, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.messages <- &queuedMessage{msg: msg}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones to be delivered
+// messages waiting to be retried are given one last attempt straight away
+func (q *Queue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	var retryNow []*queuedMessage
+	for queued, timer := range q.retries {
+		// timers that already fired are requeued by their own callback
+		if timer.Stop() {
+			retryNow = append(retryNow, queued)
+			delete(q.retries, queued)
+		}
+	}
+	q.mu.Unlock()
+
+	for _, queued := range retryNow {
+		q.messages <- queued
+		q.pending.Done()
+	}
+	q.pending.Wait()
+
+	close(q.messages)
+	q.workers.Wait()
+}
+
+func (q *Queue) deliver(queued *queuedMessage) {
+	err := q.transport.Send(context.Background(), queued.msg)
+	if err == nil {
+		return
+	}
+
+	queued.failures++
+	if queued.failures >= q.maxAttempts {
+		fmt.Printf("Email to %v dropped after %d attempts: %s\n", queued.msg.To, queued.failures, err.Error())
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		fmt.Printf("Email to %v dropped while shutting down: %s\n", queued.msg.To, err.Error())
+		return
+	}
+
+	delay := q.retryDelay << (queued.failures - 1)
+	fmt.Printf("Email to %v failed, retrying in %s: %s\n", queued.msg.To, delay, err.Error())
+
+	q.pending.Add(1)
+	q.retries[queued] = time.AfterFunc(delay, func() {
+		q.mu.Lock()
+		delete(q.retries, queued)
+		q.mu.Unlock()
+
+		q.messages <- queued
+		q.pending.Done()
+	})
+}
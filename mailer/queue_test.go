@@ -0,0 +1,173 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fails the first failures sends to each recipient, then delivers to a MemoryMailer
+type flakyMailer struct {
+	mu       sync.Mutex
+	failures int
+	attempts map[string][]time.Time
+	sent     *MemoryMailer
+}
+
+func newFlakyMailer(failures int) *flakyMailer {
+	return &flakyMailer{failures: failures, attempts: map[string][]time.Time{}, sent: NewMemoryMailer()}
+}
+
+func (f *flakyMailer) Send(ctx context.Context, msg *Message) error {
+	f.mu.Lock()
+	to := msg.To[0]
+	f.attempts[to] = append(f.attempts[to], time.Now())
+	failed := len(f.attempts[to]) <= f.failures
+	f.mu.Unlock()
+
+	if failed {
+		return errors.New("transport unavailable")
+	}
+	return f.sent.Send(ctx, msg)
+}
+
+func (f *flakyMailer) attemptTimes(to string) []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Time{}, f.attempts[to]...)
+}
+
+func testMessage(to string) *Message {
+	return &Message{From: "blog@example.com", To: []string{to}, Subject: "Hello", Text: "Hello"}
+}
+
+// wait for a condition set by the queue's goroutines
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the queue")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestQueueRetriesWithDoublingDelay(t *testing.T) {
+	transport := newFlakyMailer(2)
+	retryDelay := 20 * time.Millisecond
+	q := NewQueue(transport, 1, 10, 5, retryDelay)
+	defer q.Close()
+
+	err := q.Send(context.Background(), testMessage("reader@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(transport.sent.Messages()) == 1 })
+
+	attempts := transport.attemptTimes("reader@example.com")
+	if len(attempts) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(attempts))
+	}
+	if gap := attempts[1].Sub(attempts[0]); gap < retryDelay {
+		t.Errorf("first retry after %s, want at least %s", gap, retryDelay)
+	}
+	if gap := attempts[2].Sub(attempts[1]); gap < 2*retryDelay {
+		t.Errorf("second retry after %s, want at least %s", gap, 2*retryDelay)
+	}
+}
+
+func TestQueueDropsAfterMaxAttempts(t *testing.T) {
+	transport := newFlakyMailer(100)
+	q := NewQueue(transport, 1, 10, 3, time.Millisecond)
+	defer q.Close()
+
+	err := q.Send(context.Background(), testMessage("reader@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(transport.attemptTimes("reader@example.com")) == 3 })
+
+	// no retry is scheduled after the last attempt
+	time.Sleep(20 * time.Millisecond)
+	if attempts := len(transport.attemptTimes("reader@example.com")); attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestQueueRetryDoesNotHoldUpOtherMessages(t *testing.T) {
+	transport := newFlakyMailer(1)
+	q := NewQueue(transport, 1, 10, 5, time.Hour)
+	defer q.Close()
+
+	err := q.Send(context.Background(), testMessage("failing@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(transport.attemptTimes("failing@example.com")) == 1 })
+
+	// the only worker is free while the failed message waits an hour to retry
+	transport.mu.Lock()
+	transport.failures = 0
+	transport.mu.Unlock()
+	err = q.Send(context.Background(), testMessage("reader@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(transport.sent.Messages()) == 1 })
+}
+
+func TestQueueCloseDeliversQueuedMessages(t *testing.T) {
+	transport := NewMemoryMailer()
+	q := NewQueue(transport, 1, 20, 1, time.Millisecond)
+
+	for i := 0; i < 20; i++ {
+		err := q.Send(context.Background(), testMessage("reader@example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	q.Close()
+
+	if sent := len(transport.Messages()); sent != 20 {
+		t.Fatalf("got %d messages sent, want 20", sent)
+	}
+}
+
+func TestQueueCloseRetriesWaitingMessagesNow(t *testing.T) {
+	transport := newFlakyMailer(1)
+	q := NewQueue(transport, 1, 10, 5, time.Hour)
+
+	err := q.Send(context.Background(), testMessage("reader@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(transport.attemptTimes("reader@example.com")) == 1 })
+
+	closed := make(chan struct{})
+	go func() {
+		q.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close waited for the retry delay")
+	}
+
+	if sent := len(transport.sent.Messages()); sent != 1 {
+		t.Fatalf("got %d messages sent, want 1", sent)
+	}
+}
+
+func TestQueueSendAfterClose(t *testing.T) {
+	q := NewQueue(NewMemoryMailer(), 1, 10, 1, time.Millisecond)
+	q.Close()
+
+	err := q.Send(context.Background(), testMessage("reader@example.com"))
+	if !errors.Is(err, ErrQueueClosed) {
+		t.Fatalf("got %v, want ErrQueueClosed", err)
+	}
+}
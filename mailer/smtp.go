@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// how the connection to the SMTP server is secured
+type TLSMode string
+
+const (
+	TLSModeStartTLS TLSMode = "starttls" // upgrade a plain connection, usually on port 587
+	TLSModeImplicit TLSMode = "tls"      // connect over TLS from the start, usually on port 465
+	TLSModeNone     TLSMode = "none"     // no encryption, only for local development servers
+)
+
+// max time for connecting to the server and sending a message
+const smtpTimeout = time.Second * 30
+
+// SMTPMailer sends messages through an SMTP server
+// messages are sent without authentication when Username is empty
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	TLSMode  TLSMode
+	Username string
+	Password string
+}
+
+func NewSMTPMailer(host string, port int, tlsMode TLSMode, username string, password string) (*SMTPMailer, error) {
+	switch tlsMode {
+	case TLSModeStartTLS, TLSModeImplicit, TLSModeNone:
+	default:
+		return nil, fmt.Errorf("mailer: unknown TLS mode %q", tlsMode)
+	}
+
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		TLSMode:  tlsMode,
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	raw, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(msg.From)
+	if err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		err = client.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(raw)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// open a connection to the server, secured according to the TLS mode
+func (m *SMTPMailer) connect(ctx context.Context) (*smtp.Client, error) {
+	address := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+	tlsConfig := &tls.Config{ServerName: m.Host}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var conn net.Conn
+	var err error
+	if m.TLSMode == TLSModeImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if m.TLSMode == TLSModeStartTLS {
+		err = client.StartTLS(tlsConfig)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
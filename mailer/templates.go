@@ -0,0 +1,93 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// each email has a .txt template, which also defines the "subject" template,
+// and an .html template with the same name
+//
+//go:embed templates/*.txt templates/*.html
+var templateFiles embed.FS
+
+// functions available to every template
+var templateFuncs = map[string]interface{}{
+	"duration": formatDuration,
+}
+
+// the parsed text and html templates of an email
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// every email is parsed once at startup, so a broken template fails immediately
+var emailTemplates = parseEmailTemplates()
+
+func parseEmailTemplates() map[string]emailTemplate {
+	textFiles, err := fs.Glob(templateFiles, "templates/*.txt")
+	if err != nil {
+		panic(err)
+	}
+
+	parsed := make(map[string]emailTemplate)
+	for _, textFile := range textFiles {
+		name := strings.TrimSuffix(path.Base(textFile), ".txt")
+		// parse each email on its own, since every text template defines its own subject
+		parsed[name] = emailTemplate{
+			text: texttemplate.Must(texttemplate.New(name+".txt").Funcs(templateFuncs).ParseFS(templateFiles, textFile)),
+			html: htmltemplate.Must(htmltemplate.New(name+".html").Funcs(templateFuncs).ParseFS(templateFiles, "templates/"+name+".html")),
+		}
+	}
+	return parsed
+}
+
+// NewTemplateMessage builds a message from the text and html templates with the given name
+func NewTemplateMessage(from string, to string, name string, data interface{}) (*Message, error) {
+	tmpl, ok := emailTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("mailer: unknown template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	err := tmpl.text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return nil, err
+	}
+	err = tmpl.text.Execute(&text, data)
+	if err != nil {
+		return nil, err
+	}
+	err = tmpl.html.Execute(&html, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		From:    from,
+		To:      []string{to},
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// format how long a link stays valid, such as "24 hours" or "30 minutes"
+func formatDuration(d time.Duration) string {
+	amount, unit := int(d/time.Minute), "minute"
+	if d >= time.Hour && d%time.Hour == 0 {
+		amount, unit = int(d/time.Hour), "hour"
+	}
+	if amount == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", amount, unit)
+}
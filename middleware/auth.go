@@ -2,12 +2,12 @@ package middleware
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 
 	sessions "github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jt-rose/clean_blog_server/apperrors"
 	"github.com/jt-rose/clean_blog_server/constants"
 )
 
@@ -24,6 +24,13 @@ type contextKey struct {
 func Authenticate() gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
 
+		// scripts and other headless clients authenticate with an API token instead of a session
+		authorization := ginContext.GetHeader("Authorization")
+		if authorization != "" {
+			authenticateAPIToken(ginContext, authorization)
+			return
+		}
+
 		// connext to existing session or generate a new one
 		// a session will always be returned
 		session := sessions.Default(ginContext)
@@ -45,7 +52,25 @@ func Authenticate() gin.HandlerFunc {
 			ginContext.Next()
 			return
 		}
-		
+
+		// sign out sessions that were revoked, such as when two-factor authentication is enabled
+		revoked, err := sessionRevoked(ginContext.Request.Context(), session, user_id)
+		if err != nil {
+			StoreErrorLog(ginContext.Request.Context(), err)
+			abortWithGraphQLError(ginContext, http.StatusInternalServerError, apperrors.CodeInternal, constants.INTERNAL_ERROR_MESSAGE)
+			return
+		}
+		if revoked {
+			session.Delete("user")
+			session.Delete("signed_in_at")
+			err = session.Save()
+			if err != nil {
+				http.Error(ginContext.Writer, err.Error(), http.StatusInternalServerError)
+			}
+			ginContext.Next()
+			return
+		}
+
 		// pass user info to context
 		ctx := context.WithValue(ginContext.Request.Context(), userCtxKey, user_id)
 
@@ -99,41 +124,3 @@ return nil, nil, err
 	return gc,
 		session, nil
 }
-
-func GetUserIDFromSessions(ctx context.Context) (int, error) {
-	_, session, err := GetGinContextAndSessions(ctx)
-	if err != nil {
-		return 0, err
-	}
-	// Retrieve our User id and type-assert it
-	// return 0 if no userID int found
-	val := session.Get("user")
-	userID, ok := val.(int)
-	if !ok {
-		return 0, nil
-	} else {
-		return userID, nil
-	}
-}
-
-func ConfirmAuthor(ctx context.Context, authorID int) (bool, int, error) {
-	userID, err := GetUserIDFromSessions(ctx)
-	if err != nil {
-		return false, 0, err
-	}
-	isAuthor := userID == authorID
-	return isAuthor, userID, nil
-}
-
-func RejectIfNotAuthor(ctx context.Context, authorID int) (error) {
-	isAuthor, _, err := ConfirmAuthor(ctx, authorID)
-		if err != nil {
-			return err
-		}
-
-		if !isAuthor {
-			return errors.New(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
-		}
-
-		return nil
-}
\ No newline at end of file
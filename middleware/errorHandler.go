@@ -9,68 +9,101 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 
+	"github.com/gofrs/uuid"
+	"github.com/jt-rose/clean_blog_server/apperrors"
 	"github.com/jt-rose/clean_blog_server/constants"
 	database "github.com/jt-rose/clean_blog_server/database"
 	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-// read the function environment and store a SQL record of the error
-func storeErrorLog(ctx context.Context, err error) error {
+// StoreErrorLog reads the function environment and stores a SQL record of the error
+// returns the correlation ID of the stored record, which is shared with the client
+// so the error can be found in the error log
+// also used for failures that do not fail the request, such as an email that could not be queued
+func StoreErrorLog(ctx context.Context, err error) string {
 	// print out data on point of failure
 	pc := make([]uintptr, 15)
 	n := runtime.Callers(2, pc)
 	frames := runtime.CallersFrames(pc[:n])
 	frame, _ := frames.Next()
+	correlationID := uuid.Must(uuid.NewV4()).String()
 	fmt.Println("Error Encountered: ", err.Error())
 	fmt.Printf("Error Found at: %s:%d %s\n", frame.File, frame.Line, frame.Function)
+	fmt.Println("Correlation ID: ", correlationID)
 
 	// add data on point of failure to error log
 	errorLog := sql_models.ErrorLog{
-		ErrMessage:  err.Error(),
-		ErrorOrigin: frame.Function,
+		ErrMessage:    err.Error(),
+		ErrorOrigin:   frame.Function,
+		CorrelationID: correlationID,
 	}
-	errorLog.Insert(ctx, database.DB, boil.Infer())
-	return err
+	insertErr := errorLog.Insert(ctx, database.DB, boil.Infer())
+	if insertErr != nil {
+		// keep the correlation ID findable in the server logs
+		// when the error log itself cannot be written to
+		fmt.Printf("Error Log Failed: %s (correlation ID %s)\n", insertErr.Error(), correlationID)
+	}
+	return correlationID
 }
 
-// parse errors and hide select error messages from end user
+// format errors for the client, adding the error code to extensions.code
+// typed errors are shared directly, while other errors are stored in the error log
+// and hidden behind a generic message and the correlation ID of the log
 func HandleErrors(ctx context.Context, e error) *gqlerror.Error {
 	err := graphql.DefaultErrorPresenter(ctx, e)
 
-	// if data not found, return user-friendly error message
-	if err.Message == "sql: no rows in result set" {
-		err.Message = "No matching data found in database"
-		// if custom error not found, store the issue in the error log
+	// errors from parsing or validating the request itself
+	// are created by gqlgen without an underlying error
+	if errors.Unwrap(err) == nil && err.Extensions["code"] == nil {
+		setErrorCode(err, apperrors.CodeValidation)
+		return err
+	}
+
+	appErr, ok := apperrors.As(e)
+	if !ok {
+		// if the error is not typed, store the issue in the error log
 		// and hide the details of the error from the client
-	} else if !constants.IsCustomError(err.Message) {
-		storeErrorLog(ctx, err)
-		// provide generic response to hide error details from the client
-		err.Message = "data currently unavailable"
+		appErr = apperrors.Internal(constants.INTERNAL_ERROR_MESSAGE, StoreErrorLog(ctx, e), e)
+	}
 
+	err.Message = appErr.Error()
+	setErrorCode(err, appErr.Code)
+	if appErr.Field != "" {
+		err.Extensions["field"] = appErr.Field
 	}
-	// return newly formatted error or custom error
+	if appErr.CorrelationID != "" {
+		err.Extensions["correlation_id"] = appErr.CorrelationID
+	}
+
+	// return newly formatted error
 	return err
 }
 
+func setErrorCode(err *gqlerror.Error, code apperrors.Code) {
+	if err.Extensions == nil {
+		err.Extensions = map[string]interface{}{}
+	}
+	err.Extensions["code"] = code
+}
+
 // store error log and format message when recovering from a panic
 // to be used with the gql server.SetRecoverFunc function
 func HandlePanics(ctx context.Context, err interface{}) error {
 	var foundError error
-	genericError := errors.New("Internal server error!")
-	// run type assertion to confirm err is a map
+	// run type assertion to confirm err is an error
 	errorStruct, ok := err.(error)
 	if ok {
 		foundError = errorStruct
 	} else {
-		foundError = genericError
+		foundError = fmt.Errorf("panic: %v", err)
 	}
 
 	// notify bug tracker and print to console
 	// store detailed error message for error log
-	// but only show "Internal Server Error" for end users
+	// but only show "Internal server error!" and the correlation ID to end users
 	fmt.Println(foundError.Error())
-	storeErrorLog(ctx, foundError)
+	correlationID := StoreErrorLog(ctx, foundError)
 
-	return genericError
+	return apperrors.Internal(constants.PANIC_ERROR_MESSAGE, correlationID, foundError)
 }
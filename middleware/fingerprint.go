@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// headers that together roughly identify a client across accounts
+var fingerprintHeaders = []string{"User-Agent", "Accept-Language", "Accept-Encoding"}
+
+// GetClientFingerprint returns the client's IP address and a hash of its request headers
+// used to spot several accounts voting from the same client
+func GetClientFingerprint(ctx context.Context) (string, string, error) {
+	gc, err := GinContextFromContext(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := sha256.New()
+	for _, header := range fingerprintHeaders {
+		hash.Write([]byte(gc.GetHeader(header)))
+		hash.Write([]byte{0})
+	}
+
+	return gc.ClientIP(), hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// roles stored in the roles table
+const (
+	RoleAdmin     = "admin"
+	RoleAuthor    = "author"
+	RoleModerator = "moderator"
+	RoleReader    = "reader"
+)
+
+// actions a role may allow
+type Permission string
+
+const (
+	PermissionWritePosts       Permission = "write_posts"       // add posts and edit your own
+	PermissionEditAnyPost      Permission = "edit_any_post"     // edit, delete, or restore any post
+	PermissionModerateComments Permission = "moderate_comments" // delete or restore any comment
+	PermissionReviewVotes      Permission = "review_votes"      // review and reinstate flagged votes
+	PermissionManageRoles      Permission = "manage_roles"      // grant and revoke roles
+)
+
+// permissions allowed by each role
+// readers may comment and vote, which only requires being signed in
+var rolePermissions = map[string][]Permission{
+	RoleAdmin: {
+		PermissionWritePosts,
+		PermissionEditAnyPost,
+		PermissionModerateComments,
+		PermissionReviewVotes,
+		PermissionManageRoles,
+	},
+	RoleAuthor:    {PermissionWritePosts},
+	RoleModerator: {PermissionModerateComments},
+	RoleReader:    {},
+}
+
+// GetUserRoles returns the names of the roles granted to a user
+// roles are cached on the gin context, so each user's roles are looked up once per request
+func GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+	if userID == 0 {
+		return []string{}, nil
+	}
+
+	gc, err := GinContextFromContext(ctx)
+	cacheKey := "user_roles:" + strconv.Itoa(userID)
+	if err == nil {
+		if cached, ok := gc.Get(cacheKey); ok {
+			return cached.([]string), nil
+		}
+	}
+
+	roles, err := LoadUserRoles(ctx, database.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if gc != nil {
+		gc.Set(cacheKey, roles)
+	}
+	return roles, nil
+}
+
+// LoadUserRoles reads the names of the roles granted to a user from the database
+func LoadUserRoles(ctx context.Context, exec boil.ContextExecutor, userID int) ([]string, error) {
+	var userRoles []struct {
+		RoleName string `boil:"role_name"`
+	}
+	err := queries.Raw(`SELECT r.role_name FROM user_roles ur
+		INNER JOIN roles r ON r.role_id = ur.role_id
+		WHERE ur.user_id = $1
+		ORDER BY r.role_name`, userID).Bind(ctx, exec, &userRoles)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	roles := []string{}
+	for _, role := range userRoles {
+		roles = append(roles, role.RoleName)
+	}
+	return roles, nil
+}
+
+// HasPermission confirms if any of a user's roles allow a permission
+// signed out users have no permissions
+func HasPermission(ctx context.Context, userID int, permission Permission) (bool, error) {
+	roles, err := GetUserRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		for _, allowed := range rolePermissions[role] {
+			if allowed == permission {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// RequirePermission gets the signed in user and rejects the request
+// if they are signed out or their roles do not allow the permission
+func RequirePermission(ctx context.Context, permission Permission) (int, error) {
+	userID := GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return 0, apperrors.Unauthenticated(constants.UNAUTHENTICATED_ERROR_MESSAGE)
+	}
+
+	allowed, err := HasPermission(ctx, userID, permission)
+	if err != nil {
+		return 0, err
+	}
+	if !allowed {
+		return 0, apperrors.Forbidden(constants.PERMISSION_DENIED_ERROR_MESSAGE)
+	}
+
+	return userID, nil
+}
+
+// CanEditPost confirms if a user may edit, delete, restore, or view the history of a post
+// authors may manage their own posts, and admins may manage any post
+func CanEditPost(ctx context.Context, userID int, post *sql_models.Post) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+
+	if post.UserID == userID {
+		return HasPermission(ctx, userID, PermissionWritePosts)
+	}
+	return HasPermission(ctx, userID, PermissionEditAnyPost)
+}
+
+// RejectIfCannotEditPost gets the signed in user and rejects the request
+// if they are not allowed to edit the post
+func RejectIfCannotEditPost(ctx context.Context, post *sql_models.Post) (int, error) {
+	userID := GetUserIDFromContext(ctx)
+	if userID == 0 {
+		return 0, apperrors.Unauthenticated(constants.UNAUTHENTICATED_ERROR_MESSAGE)
+	}
+
+	allowed, err := CanEditPost(ctx, userID, post)
+	if err != nil {
+		return 0, err
+	}
+	if !allowed {
+		return 0, apperrors.Forbidden(constants.ONLY_AUTHOR_ALLOWED_ERROR_MESSAGE)
+	}
+
+	return userID, nil
+}
+
+// CanModerateComment confirms if a user may delete or restore a comment
+// users may manage their own comments, and moderators may manage any comment
+func CanModerateComment(ctx context.Context, userID int, comment *sql_models.Comment) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+
+	if comment.UserID == userID {
+		return true, nil
+	}
+	return HasPermission(ctx, userID, PermissionModerateComments)
+}
+
+// HasRole confirms if a user has been granted a role
+// admins are treated as having every role
+func HasRole(ctx context.Context, userID int, role string) (bool, error) {
+	roles, err := GetUserRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, granted := range roles {
+		if granted == role || granted == RoleAdmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsEmailVerified confirms if a user has verified their email address
+func IsEmailVerified(ctx context.Context, userID int) (bool, error) {
+	return sql_models.Users(
+		qm.Where("user_id = ?", userID),
+		qm.Where("email_verified_at IS NOT NULL"),
+	).Exists(ctx, database.DB)
+}
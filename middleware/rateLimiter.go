@@ -1,13 +1,20 @@
 package middleware
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
 	rdb "github.com/jt-rose/clean_blog_server/database"
 	limiter "github.com/ulule/limiter/v3"
 	mgin "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	sredis "github.com/ulule/limiter/v3/drivers/store/redis"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 func InitRateLimiter() (gin.HandlerFunc, error) {
@@ -30,5 +37,46 @@ func InitRateLimiter() (gin.HandlerFunc, error) {
 	}
 
 	// return the customized gin middleware
-	return mgin.NewMiddleware(limiter.New(store, rate)), nil
+	return mgin.NewMiddleware(limiter.New(store, rate), mgin.WithLimitReachedHandler(handleLimitReached)), nil
+}
+
+// respond with a graphQL formatted error, so clients can check
+// extensions.code the same way as for resolver errors
+func handleLimitReached(c *gin.Context) {
+	abortWithGraphQLError(c, http.StatusTooManyRequests, apperrors.CodeRateLimited, constants.RATE_LIMITED_ERROR_MESSAGE)
+}
+
+// abort a request before it reaches the graphQL handler with a graphQL formatted error
+func abortWithGraphQLError(c *gin.Context, status int, code apperrors.Code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"errors": []*gqlerror.Error{{
+			Message:    message,
+			Extensions: map[string]interface{}{"code": code},
+		}},
+	})
+}
+
+// add an attempt and start the window with the first one in a single step,
+// so a failure between the two cannot leave a counter that never expires
+var limitAttemptsScript = redis.NewScript(`
+local attempts = redis.call("INCR", KEYS[1])
+if attempts == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return attempts
+`)
+
+// LimitAttempts counts an attempt at a sensitive action, such as requesting a password reset,
+// and returns a rate limited error once more than limit attempts share the key within the window
+func LimitAttempts(ctx context.Context, key string, limit int, window time.Duration) error {
+	attemptsKey := "attempts:" + key
+	attempts, err := limitAttemptsScript.Run(ctx, rdb.RedisClient, []string{attemptsKey}, window.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+
+	if attempts > int64(limit) {
+		return apperrors.RateLimited(constants.RATE_LIMITED_ERROR_MESSAGE)
+	}
+	return nil
 }
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	sessions "github.com/gin-contrib/sessions"
+	"github.com/go-redis/redis/v8"
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+)
+
+// the session store cannot list a user's sessions, so revoking them stores the time instead
+// and sessions signed in before that time are signed out when they are next used
+const sessionsRevokedPrefix = "sessions_revoked:"
+
+// SignInSession stores the signed in user in the session, along with when they signed in
+// the caller still saves the session
+func SignInSession(session sessions.Session, userID int) {
+	session.Set("user", userID)
+	session.Set("signed_in_at", time.Now().UnixNano())
+}
+
+// RevokeOtherSessions signs the user out of every session except the one making the request
+func RevokeOtherSessions(ctx context.Context, userID int) error {
+	_, session, err := GetGinContextAndSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	// kept for as long as a session can last, after which every older session has expired anyway
+	revokedAt := time.Now().UnixNano()
+	err = database.RedisClient.Set(ctx, sessionsRevokedPrefix+strconv.Itoa(userID), revokedAt, constants.SESSION_MAX_AGE).Err()
+	if err != nil {
+		return err
+	}
+
+	session.Set("signed_in_at", revokedAt)
+	return session.Save()
+}
+
+// confirm the session was signed in after the user's sessions were last revoked
+// sessions signed in before sessions were tracked have no signed_in_at and count as revoked
+func sessionRevoked(ctx context.Context, session sessions.Session, userID int) (bool, error) {
+	revokedAt, err := database.RedisClient.Get(ctx, sessionsRevokedPrefix+strconv.Itoa(userID)).Int64()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	signedInAt, _ := session.Get("signed_in_at").(int64)
+	return signedInAt < revokedAt, nil
+}
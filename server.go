@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
 	// graphQL handlers
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
@@ -21,14 +28,18 @@ import (
 	helmet "github.com/danielkov/gin-helmet"
 	ENV "github.com/jt-rose/clean_blog_server/constants"
 	database "github.com/jt-rose/clean_blog_server/database"
+	jobs "github.com/jt-rose/clean_blog_server/jobs"
+	"github.com/jt-rose/clean_blog_server/mailer"
 	middleware "github.com/jt-rose/clean_blog_server/middleware"
 )
 
 // Defining the Graphql handler
 func graphqlHandler() gin.HandlerFunc {
 	// initialize GraphQL server
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{}}))
+	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{}, Directives: graph.Directives()}))
 	srv.AroundOperations(middleware.HandleLogs)
+	// keep API tokens to the mutations their scopes cover
+	srv.AroundFields(middleware.RestrictAPITokenMutations)
 	// set up error and panic handling
 	srv.SetErrorPresenter(middleware.HandleErrors)
 	srv.SetRecoverFunc(middleware.HandlePanics)
@@ -54,6 +65,31 @@ func main() {
 	DB := database.DB
 	defer DB.Close()
 
+	// send emails in the background and deliver any still queued on shutdown
+	transport, err := mailer.NewTransport(mailer.TransportConfig{
+		Transport: ENV.ENV_VARIABLES.MAIL_TRANSPORT,
+		SMTPHost:  ENV.ENV_VARIABLES.SMTP_HOST,
+		SMTPPort:  ENV.ENV_VARIABLES.SMTP_PORT,
+		TLSMode:   mailer.TLSMode(ENV.ENV_VARIABLES.SMTP_TLS_MODE),
+		Username:  ENV.ENV_VARIABLES.EMAIL_ADDRESS,
+		Password:  ENV.ENV_VARIABLES.EMAIL_PASSWORD,
+		Maildir:   ENV.ENV_VARIABLES.MAILDIR,
+	})
+	if err != nil {
+		log.Fatalf("Unable to set up email: %v", err)
+	}
+	outbox := mailer.StartOutbox(transport, ENV.MAIL_QUEUE_WORKERS, ENV.MAIL_QUEUE_SIZE, ENV.MAIL_MAX_ATTEMPTS, ENV.MAIL_RETRY_DELAY)
+	defer outbox.Close()
+
+	// permanently remove expired posts and comments from the trash
+	jobs.StartTrashPurge(ENV.TRASH_PURGE_INTERVAL)
+	// publish scheduled posts once they are due
+	jobs.StartScheduledPublisher(ENV.SCHEDULED_PUBLISH_INTERVAL)
+	// recompute site-wide post rankings for the trending and front page queries
+	jobs.StartPostRanker(ENV.POST_RANKING_INTERVAL)
+	// flag votes matching sock-puppet patterns for admin review
+	jobs.StartVoteAnalyzer(ENV.VOTE_ANALYSIS_INTERVAL)
+
 	// setting up Gin
 	r := gin.Default()
 	r.SetTrustedProxies([]string{"192.168.1.2"})
@@ -75,6 +111,28 @@ func main() {
 	r.POST("/query", graphqlHandler())
 	r.GET("/", playgroundHandler())
 
-	// run on default available ports
-	r.Run()
+	// run on the port set by the PORT environment variable, or 8080 by default
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	server := &http.Server{Addr: ":" + port, Handler: r}
+
+	// stop accepting requests on shutdown and let the deferred cleanup run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ENV.SHUTDOWN_TIMEOUT)
+	defer cancel()
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Println("Shutdown failed: ", err.Error())
+	}
 }
@@ -0,0 +1,19 @@
+-- backfills for databases created before a table or column existed
+-- each statement is safe to run more than once
+
+-- roles: every existing user may comment and vote,
+-- and users who have already written posts keep the ability to manage them
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, r.role_id FROM users u CROSS JOIN roles r
+WHERE r.role_name = 'reader'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT DISTINCT p.user_id, r.role_id FROM posts p CROSS JOIN roles r
+WHERE r.role_name = 'author'
+ON CONFLICT DO NOTHING;
+
+-- email verification: users who registered before addresses were verified are treated as verified,
+-- so they can still reset their password, adding the column with a default fills in only the existing rows
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ DEFAULT now();
+ALTER TABLE users ALTER COLUMN email_verified_at DROP DEFAULT;
@@ -6,17 +6,47 @@ CREATE TABLE users (
   email VARCHAR(255) UNIQUE NOT NULL,
   user_password VARCHAR(255) NOT NULL,
   created_at TIMESTAMPTZ NOT NULL,
-  active BOOLEAN NOT NULL DEFAULT TRUE
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  reputation INT NOT NULL DEFAULT 0, -- denormalized from reputation_events, updated with each vote
+  email_verified_at TIMESTAMPTZ, -- null until the user follows the link sent to their email, existing users are backfilled by backfills.sql
+  totp_secret TEXT, -- encrypted, set when two-factor enrollment starts
+  totp_enabled_at TIMESTAMPTZ, -- null until the user confirms enrollment with a code
+  totp_last_step BIGINT -- the last time step used to sign in, so a code cannot be reused
+);
+
+-- roles grant permissions, which are mapped to each role in middleware/permissions.go
+CREATE TABLE roles (
+  role_id SERIAL PRIMARY KEY,
+  role_name VARCHAR(50) UNIQUE NOT NULL
+);
+
+INSERT INTO roles (role_name) VALUES ('admin'), ('author'), ('moderator'), ('reader');
+
+-- roles granted to particular users. Maps to SQL Many to Many relationship.
+-- new users are given the roles in DEFAULT_USER_ROLES, existing users are given roles by backfills.sql
+-- the first admin must be granted directly:
+-- INSERT INTO user_roles SELECT <user_id>, role_id FROM roles WHERE role_name = 'admin';
+CREATE TABLE user_roles (
+  user_id INT REFERENCES Users(user_id) NOT NULL,
+  role_id INT REFERENCES Roles(role_id) NOT NULL,
+  PRIMARY KEY(user_id, role_id)
 );
 
 CREATE TABLE posts (
   post_id SERIAL PRIMARY KEY,
   user_id INT REFERENCES Users(user_id) NOT NULL,
   title VARCHAR(255) UNIQUE NOT NULL,
+  slug VARCHAR(255) NOT NULL, -- url-friendly version of the title, unique per author
   subtitle VARCHAR(255), NOT NULL,
   post_text TEXT NOT NULL, -- may change to JSONB based on react editor
+  format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (format IN ('markdown', 'html', 'plain')),
+  upvotes INT NOT NULL DEFAULT 0, -- denormalized from post_votes, updated with each vote
+  downvotes INT NOT NULL DEFAULT 0,
   created_at TIMESTAMPTZ NOT NULL,
-  published BOOLEAN NOT NULL DEFAULT TRUE
+  published BOOLEAN NOT NULL DEFAULT TRUE,
+  publish_at TIMESTAMPTZ, -- nullable, set when the post is scheduled to be published later
+  deleted_at TIMESTAMPTZ, -- nullable, set when the post is moved to the trash
+  UNIQUE (user_id, slug)
 );
 
 CREATE TABLE comments (
@@ -25,26 +55,199 @@ CREATE TABLE comments (
   post_id INT REFERENCES Posts(post_id) NOT NULL,
   user_id INT REFERENCES Users(user_id) NOT NULL,
   comment_text TEXT NOT NULL,
+  format VARCHAR(10) NOT NULL DEFAULT 'plain' CHECK (format IN ('markdown', 'html', 'plain')),
+  upvotes INT NOT NULL DEFAULT 0, -- denormalized from comment_votes, updated with each vote
+  downvotes INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL,
+  deleted_at TIMESTAMPTZ -- nullable, set when the comment is moved to the trash
+);
+
+-- previous slugs for each post, so links using an old slug can be redirected
+CREATE TABLE post_slug_history (
+  user_id INT REFERENCES Users(user_id) NOT NULL,
+  slug VARCHAR(255) NOT NULL,
+  post_id INT REFERENCES Posts(post_id) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY(user_id, slug)
+);
+
+-- snapshot of a post taken before each edit, used for revision history and rollback
+CREATE TABLE post_revisions (
+  revision_id SERIAL PRIMARY KEY,
+  post_id INT REFERENCES Posts(post_id) NOT NULL,
+  editor_id INT REFERENCES Users(user_id) NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  subtitle VARCHAR(255) NOT NULL,
+  post_text TEXT NOT NULL,
+  format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (format IN ('markdown', 'html', 'plain')),
   created_at TIMESTAMPTZ NOT NULL
 );
 
+CREATE INDEX post_revisions_post_id_idx ON post_revisions (post_id, created_at DESC);
+
+CREATE TABLE tags (
+  tag_id SERIAL PRIMARY KEY,
+  tag_name VARCHAR(50) UNIQUE NOT NULL -- stored lowercase
+);
+
+-- tags applied to particular posts. Maps to SQL Many to Many relationship.
+CREATE TABLE post_tags (
+  post_id INT REFERENCES Posts(post_id) NOT NULL,
+  tag_id INT REFERENCES Tags(tag_id) NOT NULL,
+  PRIMARY KEY(post_id, tag_id)
+);
+
+CREATE INDEX post_tags_tag_id_idx ON post_tags (tag_id);
+
+-- full-text search vectors, weighted so title matches rank above subtitle and body matches
+-- generated columns are skipped by sqlboiler and maintained by postgres
+ALTER TABLE posts ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  setweight(to_tsvector('english', title), 'A') ||
+  setweight(to_tsvector('english', subtitle), 'B') ||
+  setweight(to_tsvector('english', post_text), 'C')
+) STORED;
+
+ALTER TABLE comments ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  to_tsvector('english', comment_text)
+) STORED;
+
+CREATE INDEX posts_search_vector_idx ON posts USING GIN (search_vector);
+CREATE INDEX comments_search_vector_idx ON comments USING GIN (search_vector);
+
+-- reddit-style hot ranking, newer items need fewer votes to rank highly
+-- created_at is part of the score, so the ranking only changes when votes do
+CREATE FUNCTION hot_score(upvotes BIGINT, downvotes BIGINT, created_at TIMESTAMPTZ) RETURNS DOUBLE PRECISION AS $$
+  SELECT (
+    SIGN(upvotes - downvotes) * LOG(GREATEST(ABS(upvotes - downvotes), 1))
+    + (EXTRACT(EPOCH FROM created_at) - 1134028003) / 45000
+  )::DOUBLE PRECISION
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- ranks items with many votes split evenly between up and down highest
+CREATE FUNCTION controversy_score(upvotes BIGINT, downvotes BIGINT) RETURNS DOUBLE PRECISION AS $$
+  SELECT CASE WHEN upvotes <= 0 OR downvotes <= 0 THEN 0
+    ELSE POWER((upvotes + downvotes)::DOUBLE PRECISION, LEAST(upvotes, downvotes)::DOUBLE PRECISION / GREATEST(upvotes, downvotes))
+  END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- site-wide post scores, recomputed periodically by the ranking job
+-- so the trending and front page queries only read a single page of rows
+-- trending scores are null for posts with no activity inside the window
+CREATE TABLE post_rankings (
+  post_id INT PRIMARY KEY REFERENCES Posts(post_id),
+  hot_score DOUBLE PRECISION NOT NULL,
+  top_score DOUBLE PRECISION NOT NULL,
+  trending_day DOUBLE PRECISION,
+  trending_week DOUBLE PRECISION,
+  trending_month DOUBLE PRECISION,
+  computed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX post_rankings_hot_idx ON post_rankings (hot_score DESC, post_id DESC);
+CREATE INDEX post_rankings_top_idx ON post_rankings (top_score DESC, post_id DESC);
+CREATE INDEX post_rankings_day_idx ON post_rankings (trending_day DESC, post_id DESC) WHERE trending_day IS NOT NULL;
+CREATE INDEX post_rankings_week_idx ON post_rankings (trending_week DESC, post_id DESC) WHERE trending_week IS NOT NULL;
+CREATE INDEX post_rankings_month_idx ON post_rankings (trending_month DESC, post_id DESC) WHERE trending_month IS NOT NULL;
+
+-- indexes used for keyset pagination on (created_at, id)
+CREATE INDEX posts_user_id_created_at_idx ON posts (user_id, created_at DESC, post_id DESC);
+CREATE INDEX comments_post_id_created_at_idx ON comments (post_id, created_at, comment_id);
+CREATE INDEX comments_response_to_created_at_idx ON comments (response_to_comment_id, created_at, comment_id);
+CREATE INDEX users_created_at_idx ON users (created_at, user_id);
+CREATE INDEX posts_created_at_idx ON posts (created_at DESC, post_id DESC);
+
+-- partial index used by the scheduled publishing job
+CREATE INDEX posts_publish_at_idx ON posts (publish_at) WHERE published = false;
+
+-- partial indexes used by the trash purge job
+CREATE INDEX posts_deleted_at_idx ON posts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX comments_deleted_at_idx ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
+
 CREATE TABLE post_votes (
   post_id INT REFERENCES Posts(post_id) NOT NULL,
   vote_value INT NOT NULL, -- 1, 0, or -1
   user_id INT REFERENCES Users(user_id) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(), -- set each time the vote is cast or changed
+  ip_address INET, -- nullable, address the vote was last cast from
+  fingerprint VARCHAR(64), -- nullable, hash of the client's request headers
+  flagged_at TIMESTAMPTZ, -- nullable, set when the vote is flagged as manipulation and left out of the totals
+  flag_reason VARCHAR(30), -- nullable, the pattern that flagged the vote
+  reinstated_at TIMESTAMPTZ, -- nullable, set when an admin reinstates a flagged vote
+  reinstated_reason VARCHAR(30), -- nullable, the pattern the vote is no longer flagged for until it is changed
   PRIMARY KEY(post_id, user_id)
 );
 
+CREATE INDEX post_votes_updated_at_idx ON post_votes (updated_at);
+CREATE INDEX post_votes_flagged_at_idx ON post_votes (flagged_at) WHERE flagged_at IS NOT NULL;
+
 CREATE TABLE comment_votes (
   comment_id INT REFERENCES Comments(comment_id) NOT NULL,
   vote_value INT NOT NULL, -- 1, 0, or -1
   user_id INT REFERENCES Users(user_id) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(), -- set each time the vote is cast or changed
+  ip_address INET, -- nullable, address the vote was last cast from
+  fingerprint VARCHAR(64), -- nullable, hash of the client's request headers
+  flagged_at TIMESTAMPTZ, -- nullable, set when the vote is flagged as manipulation and left out of the totals
+  flag_reason VARCHAR(30), -- nullable, the pattern that flagged the vote
+  reinstated_at TIMESTAMPTZ, -- nullable, set when an admin reinstates a flagged vote
+  reinstated_reason VARCHAR(30), -- nullable, the pattern the vote is no longer flagged for until it is changed
   PRIMARY KEY(comment_id, user_id)
 );
 
+CREATE INDEX comment_votes_updated_at_idx ON comment_votes (updated_at);
+CREATE INDEX comment_votes_flagged_at_idx ON comment_votes (flagged_at) WHERE flagged_at IS NOT NULL;
+
+-- ledger of the reputation each vote gives the author of a post or comment
+-- a changed vote records the difference from the previous one, so points always sum to the current total
+-- the post or comment is cleared when it is purged from the trash, but the reputation is kept
+CREATE TABLE reputation_events (
+  event_id SERIAL PRIMARY KEY,
+  user_id INT REFERENCES Users(user_id) NOT NULL, -- the author receiving the reputation
+  voter_id INT REFERENCES Users(user_id) NOT NULL,
+  post_id INT REFERENCES Posts(post_id) ON DELETE SET NULL, -- nullable, set for votes on posts
+  comment_id INT REFERENCES Comments(comment_id) ON DELETE SET NULL, -- nullable, set for votes on comments
+  points INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX reputation_events_created_at_idx ON reputation_events (created_at, user_id);
+CREATE INDEX reputation_events_user_id_idx ON reputation_events (user_id);
+
+-- one-time codes for signing in when the user's authenticator is unavailable
+-- only a keyed hash of each code is stored
+CREATE TABLE recovery_codes (
+  code_id SERIAL PRIMARY KEY,
+  user_id INT REFERENCES Users(user_id) ON DELETE CASCADE NOT NULL,
+  code_hash VARCHAR(64) NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes (user_id);
+
+-- personal access tokens for calling the API from scripts with an Authorization: Bearer header
+-- only a hash of each token is stored, along with its first characters for telling tokens apart
+CREATE TABLE api_tokens (
+  token_id SERIAL PRIMARY KEY,
+  user_id INT REFERENCES Users(user_id) ON DELETE CASCADE NOT NULL,
+  token_name VARCHAR(100) NOT NULL,
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  token_prefix VARCHAR(12) NOT NULL,
+  scopes VARCHAR(255) NOT NULL, -- space separated, such as 'posts:write comments:write'
+  expires_at TIMESTAMPTZ, -- null for tokens that never expire
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX api_tokens_user_id_idx ON api_tokens (user_id);
+
 CREATE TABLE error_log (
     log_id SERIAL PRIMARY KEY,
     err_message TEXT NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
-    error_origin VARCHAR(255) NOT NULL
+    error_origin VARCHAR(255) NOT NULL,
+    correlation_id VARCHAR(36) NOT NULL UNIQUE
 );
\ No newline at end of file
@@ -4,17 +4,37 @@
 package models
 
 var TableNames = struct {
-	CommentVotes string
-	Comments     string
-	ErrorLog     string
-	PostVotes    string
-	Posts        string
-	Users        string
+	APITokens        string
+	CommentVotes     string
+	Comments         string
+	ErrorLog         string
+	PostRankings     string
+	PostRevisions    string
+	PostSlugHistory  string
+	PostTags         string
+	PostVotes        string
+	Posts            string
+	RecoveryCodes    string
+	ReputationEvents string
+	Roles            string
+	Tags             string
+	UserRoles        string
+	Users            string
 }{
-	CommentVotes: "comment_votes",
-	Comments:     "comments",
-	ErrorLog:     "error_log",
-	PostVotes:    "post_votes",
-	Posts:        "posts",
-	Users:        "users",
+	APITokens:        "api_tokens",
+	CommentVotes:     "comment_votes",
+	Comments:         "comments",
+	ErrorLog:         "error_log",
+	PostRankings:     "post_rankings",
+	PostRevisions:    "post_revisions",
+	PostSlugHistory:  "post_slug_history",
+	PostTags:         "post_tags",
+	PostVotes:        "post_votes",
+	Posts:            "posts",
+	RecoveryCodes:    "recovery_codes",
+	ReputationEvents: "reputation_events",
+	Roles:            "roles",
+	Tags:             "tags",
+	UserRoles:        "user_roles",
+	Users:            "users",
 }
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,67 +24,124 @@ import (
 
 // CommentVote is an object representing the database table.
 type CommentVote struct {
-	CommentID int `boil:"comment_id" json:"comment_id" toml:"comment_id" yaml:"comment_id"`
-	VoteValue int `boil:"vote_value" json:"vote_value" toml:"vote_value" yaml:"vote_value"`
-	UserID    int `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CommentID        int         `boil:"comment_id" json:"comment_id" toml:"comment_id" yaml:"comment_id"`
+	VoteValue        int         `boil:"vote_value" json:"vote_value" toml:"vote_value" yaml:"vote_value"`
+	UserID           int         `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	IPAddress        null.String `boil:"ip_address" json:"ip_address,omitempty" toml:"ip_address" yaml:"ip_address,omitempty"`
+	Fingerprint      null.String `boil:"fingerprint" json:"fingerprint,omitempty" toml:"fingerprint" yaml:"fingerprint,omitempty"`
+	FlaggedAt        null.Time   `boil:"flagged_at" json:"flagged_at,omitempty" toml:"flagged_at" yaml:"flagged_at,omitempty"`
+	FlagReason       null.String `boil:"flag_reason" json:"flag_reason,omitempty" toml:"flag_reason" yaml:"flag_reason,omitempty"`
+	ReinstatedAt     null.Time   `boil:"reinstated_at" json:"reinstated_at,omitempty" toml:"reinstated_at" yaml:"reinstated_at,omitempty"`
+	ReinstatedReason null.String `boil:"reinstated_reason" json:"reinstated_reason,omitempty" toml:"reinstated_reason" yaml:"reinstated_reason,omitempty"`
 
 	R *commentVoteR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L commentVoteL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var CommentVoteColumns = struct {
-	CommentID string
-	VoteValue string
-	UserID    string
+	CommentID        string
+	VoteValue        string
+	UserID           string
+	CreatedAt        string
+	UpdatedAt        string
+	IPAddress        string
+	Fingerprint      string
+	FlaggedAt        string
+	FlagReason       string
+	ReinstatedAt     string
+	ReinstatedReason string
 }{
-	CommentID: "comment_id",
-	VoteValue: "vote_value",
-	UserID:    "user_id",
+	CommentID:        "comment_id",
+	VoteValue:        "vote_value",
+	UserID:           "user_id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	IPAddress:        "ip_address",
+	Fingerprint:      "fingerprint",
+	FlaggedAt:        "flagged_at",
+	FlagReason:       "flag_reason",
+	ReinstatedAt:     "reinstated_at",
+	ReinstatedReason: "reinstated_reason",
 }
 
 var CommentVoteTableColumns = struct {
-	CommentID string
-	VoteValue string
-	UserID    string
+	CommentID        string
+	VoteValue        string
+	UserID           string
+	CreatedAt        string
+	UpdatedAt        string
+	IPAddress        string
+	Fingerprint      string
+	FlaggedAt        string
+	FlagReason       string
+	ReinstatedAt     string
+	ReinstatedReason string
 }{
-	CommentID: "comment_votes.comment_id",
-	VoteValue: "comment_votes.vote_value",
-	UserID:    "comment_votes.user_id",
+	CommentID:        "comment_votes.comment_id",
+	VoteValue:        "comment_votes.vote_value",
+	UserID:           "comment_votes.user_id",
+	CreatedAt:        "comment_votes.created_at",
+	UpdatedAt:        "comment_votes.updated_at",
+	IPAddress:        "comment_votes.ip_address",
+	Fingerprint:      "comment_votes.fingerprint",
+	FlaggedAt:        "comment_votes.flagged_at",
+	FlagReason:       "comment_votes.flag_reason",
+	ReinstatedAt:     "comment_votes.reinstated_at",
+	ReinstatedReason: "comment_votes.reinstated_reason",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
+type whereHelpernull_String struct{ field string }
 
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var CommentVoteWhere = struct {
-	CommentID whereHelperint
-	VoteValue whereHelperint
-	UserID    whereHelperint
+	CommentID        whereHelperint
+	VoteValue        whereHelperint
+	UserID           whereHelperint
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	IPAddress        whereHelpernull_String
+	Fingerprint      whereHelpernull_String
+	FlaggedAt        whereHelpernull_Time
+	FlagReason       whereHelpernull_String
+	ReinstatedAt     whereHelpernull_Time
+	ReinstatedReason whereHelpernull_String
 }{
-	CommentID: whereHelperint{field: "\"comment_votes\".\"comment_id\""},
-	VoteValue: whereHelperint{field: "\"comment_votes\".\"vote_value\""},
-	UserID:    whereHelperint{field: "\"comment_votes\".\"user_id\""},
+	CommentID:        whereHelperint{field: "\"comment_votes\".\"comment_id\""},
+	VoteValue:        whereHelperint{field: "\"comment_votes\".\"vote_value\""},
+	UserID:           whereHelperint{field: "\"comment_votes\".\"user_id\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"comment_votes\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"comment_votes\".\"updated_at\""},
+	IPAddress:        whereHelpernull_String{field: "\"comment_votes\".\"ip_address\""},
+	Fingerprint:      whereHelpernull_String{field: "\"comment_votes\".\"fingerprint\""},
+	FlaggedAt:        whereHelpernull_Time{field: "\"comment_votes\".\"flagged_at\""},
+	FlagReason:       whereHelpernull_String{field: "\"comment_votes\".\"flag_reason\""},
+	ReinstatedAt:     whereHelpernull_Time{field: "\"comment_votes\".\"reinstated_at\""},
+	ReinstatedReason: whereHelpernull_String{field: "\"comment_votes\".\"reinstated_reason\""},
 }
 
 // CommentVoteRels is where relationship names are stored.
@@ -110,9 +168,9 @@ func (*commentVoteR) NewStruct() *commentVoteR {
 type commentVoteL struct{}
 
 var (
-	commentVoteAllColumns            = []string{"comment_id", "vote_value", "user_id"}
-	commentVoteColumnsWithoutDefault = []string{"comment_id", "vote_value", "user_id"}
-	commentVoteColumnsWithDefault    = []string{}
+	commentVoteAllColumns            = []string{"comment_id", "vote_value", "user_id", "created_at", "updated_at", "ip_address", "fingerprint", "flagged_at", "flag_reason", "reinstated_at", "reinstated_reason"}
+	commentVoteColumnsWithoutDefault = []string{"comment_id", "vote_value", "user_id", "ip_address", "fingerprint", "flagged_at", "flag_reason", "reinstated_at", "reinstated_reason"}
+	commentVoteColumnsWithDefault    = []string{"created_at", "updated_at"}
 	commentVotePrimaryKeyColumns     = []string{"comment_id", "user_id"}
 )
 
@@ -765,6 +823,16 @@ func (o *CommentVote) Insert(ctx context.Context, exec boil.ContextExecutor, col
 	}
 
 	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
 
 	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
@@ -840,6 +908,12 @@ func (o *CommentVote) Insert(ctx context.Context, exec boil.ContextExecutor, col
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
 func (o *CommentVote) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
 	var err error
 	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
@@ -970,6 +1044,14 @@ func (o *CommentVote) Upsert(ctx context.Context, exec boil.ContextExecutor, upd
 	if o == nil {
 		return errors.New("models: no comment_votes provided for upsert")
 	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
 
 	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
 		return err
@@ -29,7 +29,11 @@ type Comment struct {
 	PostID              int       `boil:"post_id" json:"post_id" toml:"post_id" yaml:"post_id"`
 	UserID              int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
 	CommentText         string    `boil:"comment_text" json:"comment_text" toml:"comment_text" yaml:"comment_text"`
+	Format              string    `boil:"format" json:"format" toml:"format" yaml:"format"`
+	Upvotes             int       `boil:"upvotes" json:"upvotes" toml:"upvotes" yaml:"upvotes"`
+	Downvotes           int       `boil:"downvotes" json:"downvotes" toml:"downvotes" yaml:"downvotes"`
 	CreatedAt           time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	DeletedAt           null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *commentR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L commentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -41,14 +45,22 @@ var CommentColumns = struct {
 	PostID              string
 	UserID              string
 	CommentText         string
+	Format              string
+	Upvotes             string
+	Downvotes           string
 	CreatedAt           string
+	DeletedAt           string
 }{
 	CommentID:           "comment_id",
 	ResponseToCommentID: "response_to_comment_id",
 	PostID:              "post_id",
 	UserID:              "user_id",
 	CommentText:         "comment_text",
+	Format:              "format",
+	Upvotes:             "upvotes",
+	Downvotes:           "downvotes",
 	CreatedAt:           "created_at",
+	DeletedAt:           "deleted_at",
 }
 
 var CommentTableColumns = struct {
@@ -57,14 +69,22 @@ var CommentTableColumns = struct {
 	PostID              string
 	UserID              string
 	CommentText         string
+	Format              string
+	Upvotes             string
+	Downvotes           string
 	CreatedAt           string
+	DeletedAt           string
 }{
 	CommentID:           "comments.comment_id",
 	ResponseToCommentID: "comments.response_to_comment_id",
 	PostID:              "comments.post_id",
 	UserID:              "comments.user_id",
 	CommentText:         "comments.comment_text",
+	Format:              "comments.format",
+	Upvotes:             "comments.upvotes",
+	Downvotes:           "comments.downvotes",
 	CreatedAt:           "comments.created_at",
+	DeletedAt:           "comments.deleted_at",
 }
 
 // Generated where
@@ -93,64 +113,28 @@ func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
 func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var CommentWhere = struct {
 	CommentID           whereHelperint
 	ResponseToCommentID whereHelpernull_Int
 	PostID              whereHelperint
 	UserID              whereHelperint
 	CommentText         whereHelperstring
+	Format              whereHelperstring
+	Upvotes             whereHelperint
+	Downvotes           whereHelperint
 	CreatedAt           whereHelpertime_Time
+	DeletedAt           whereHelpernull_Time
 }{
 	CommentID:           whereHelperint{field: "\"comments\".\"comment_id\""},
 	ResponseToCommentID: whereHelpernull_Int{field: "\"comments\".\"response_to_comment_id\""},
 	PostID:              whereHelperint{field: "\"comments\".\"post_id\""},
 	UserID:              whereHelperint{field: "\"comments\".\"user_id\""},
 	CommentText:         whereHelperstring{field: "\"comments\".\"comment_text\""},
+	Format:              whereHelperstring{field: "\"comments\".\"format\""},
+	Upvotes:             whereHelperint{field: "\"comments\".\"upvotes\""},
+	Downvotes:           whereHelperint{field: "\"comments\".\"downvotes\""},
 	CreatedAt:           whereHelpertime_Time{field: "\"comments\".\"created_at\""},
+	DeletedAt:           whereHelpernull_Time{field: "\"comments\".\"deleted_at\""},
 }
 
 // CommentRels is where relationship names are stored.
@@ -160,21 +144,24 @@ var CommentRels = struct {
 	User                      string
 	CommentVotes              string
 	ResponseToCommentComments string
+	ReputationEvents          string
 }{
 	Post:                      "Post",
 	ResponseToComment:         "ResponseToComment",
 	User:                      "User",
 	CommentVotes:              "CommentVotes",
 	ResponseToCommentComments: "ResponseToCommentComments",
+	ReputationEvents:          "ReputationEvents",
 }
 
 // commentR is where relationships are stored.
 type commentR struct {
-	Post                      *Post            `boil:"Post" json:"Post" toml:"Post" yaml:"Post"`
-	ResponseToComment         *Comment         `boil:"ResponseToComment" json:"ResponseToComment" toml:"ResponseToComment" yaml:"ResponseToComment"`
-	User                      *User            `boil:"User" json:"User" toml:"User" yaml:"User"`
-	CommentVotes              CommentVoteSlice `boil:"CommentVotes" json:"CommentVotes" toml:"CommentVotes" yaml:"CommentVotes"`
-	ResponseToCommentComments CommentSlice     `boil:"ResponseToCommentComments" json:"ResponseToCommentComments" toml:"ResponseToCommentComments" yaml:"ResponseToCommentComments"`
+	Post                      *Post                `boil:"Post" json:"Post" toml:"Post" yaml:"Post"`
+	ResponseToComment         *Comment             `boil:"ResponseToComment" json:"ResponseToComment" toml:"ResponseToComment" yaml:"ResponseToComment"`
+	User                      *User                `boil:"User" json:"User" toml:"User" yaml:"User"`
+	CommentVotes              CommentVoteSlice     `boil:"CommentVotes" json:"CommentVotes" toml:"CommentVotes" yaml:"CommentVotes"`
+	ResponseToCommentComments CommentSlice         `boil:"ResponseToCommentComments" json:"ResponseToCommentComments" toml:"ResponseToCommentComments" yaml:"ResponseToCommentComments"`
+	ReputationEvents          ReputationEventSlice `boil:"ReputationEvents" json:"ReputationEvents" toml:"ReputationEvents" yaml:"ReputationEvents"`
 }
 
 // NewStruct creates a new relationship struct
@@ -186,9 +173,9 @@ func (*commentR) NewStruct() *commentR {
 type commentL struct{}
 
 var (
-	commentAllColumns            = []string{"comment_id", "response_to_comment_id", "post_id", "user_id", "comment_text", "created_at"}
-	commentColumnsWithoutDefault = []string{"response_to_comment_id", "post_id", "user_id", "comment_text", "created_at"}
-	commentColumnsWithDefault    = []string{"comment_id"}
+	commentAllColumns            = []string{"comment_id", "response_to_comment_id", "post_id", "user_id", "comment_text", "format", "upvotes", "downvotes", "created_at", "deleted_at"}
+	commentColumnsWithoutDefault = []string{"response_to_comment_id", "post_id", "user_id", "comment_text", "created_at", "deleted_at"}
+	commentColumnsWithDefault    = []string{"comment_id", "format", "upvotes", "downvotes"}
 	commentPrimaryKeyColumns     = []string{"comment_id"}
 )
 
@@ -551,6 +538,27 @@ func (o *Comment) ResponseToCommentComments(mods ...qm.QueryMod) commentQuery {
 	return query
 }
 
+// ReputationEvents retrieves all the reputation_event's ReputationEvents with an executor.
+func (o *Comment) ReputationEvents(mods ...qm.QueryMod) reputationEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"reputation_events\".\"comment_id\"=?", o.CommentID),
+	)
+
+	query := ReputationEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"reputation_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"reputation_events\".*"})
+	}
+
+	return query
+}
+
 // LoadPost allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (commentL) LoadPost(ctx context.Context, e boil.ContextExecutor, singular bool, maybeComment interface{}, mods queries.Applicator) error {
@@ -1063,6 +1071,104 @@ func (commentL) LoadResponseToCommentComments(ctx context.Context, e boil.Contex
 	return nil
 }
 
+// LoadReputationEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (commentL) LoadReputationEvents(ctx context.Context, e boil.ContextExecutor, singular bool, maybeComment interface{}, mods queries.Applicator) error {
+	var slice []*Comment
+	var object *Comment
+
+	if singular {
+		object = maybeComment.(*Comment)
+	} else {
+		slice = *maybeComment.(*[]*Comment)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &commentR{}
+		}
+		args = append(args, object.CommentID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &commentR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.CommentID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.CommentID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`reputation_events`),
+		qm.WhereIn(`reputation_events.comment_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load reputation_events")
+	}
+
+	var resultSlice []*ReputationEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice reputation_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on reputation_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for reputation_events")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ReputationEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &reputationEventR{}
+			}
+			foreign.R.Comment = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.CommentID, foreign.CommentID) {
+				local.R.ReputationEvents = append(local.R.ReputationEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &reputationEventR{}
+				}
+				foreign.R.Comment = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetPost of the comment to the related item.
 // Sets o.R.Post to related.
 // Adds o to related.R.Comments.
@@ -1417,6 +1523,133 @@ func (o *Comment) RemoveResponseToCommentComments(ctx context.Context, exec boil
 	return nil
 }
 
+// AddReputationEvents adds the given related objects to the existing relationships
+// of the comment, optionally inserting them as new records.
+// Appends related to o.R.ReputationEvents.
+// Sets related.R.Comment appropriately.
+func (o *Comment) AddReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.CommentID, o.CommentID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"reputation_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"comment_id"}),
+				strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.CommentID, rel.EventID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.CommentID, o.CommentID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &commentR{
+			ReputationEvents: related,
+		}
+	} else {
+		o.R.ReputationEvents = append(o.R.ReputationEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &reputationEventR{
+				Comment: o,
+			}
+		} else {
+			rel.R.Comment = o
+		}
+	}
+	return nil
+}
+
+// SetReputationEvents removes all previously related items of the
+// comment replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Comment's ReputationEvents accordingly.
+// Replaces o.R.ReputationEvents with related.
+// Sets related.R.Comment's ReputationEvents accordingly.
+func (o *Comment) SetReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	query := "update \"reputation_events\" set \"comment_id\" = null where \"comment_id\" = $1"
+	values := []interface{}{o.CommentID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ReputationEvents {
+			queries.SetScanner(&rel.CommentID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Comment = nil
+		}
+
+		o.R.ReputationEvents = nil
+	}
+	return o.AddReputationEvents(ctx, exec, insert, related...)
+}
+
+// RemoveReputationEvents relationships from objects passed in.
+// Removes related items from R.ReputationEvents (uses pointer comparison, removal does not keep order)
+// Sets related.R.Comment.
+func (o *Comment) RemoveReputationEvents(ctx context.Context, exec boil.ContextExecutor, related ...*ReputationEvent) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.CommentID, nil)
+		if rel.R != nil {
+			rel.R.Comment = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("comment_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ReputationEvents {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ReputationEvents)
+			if ln > 1 && i < ln-1 {
+				o.R.ReputationEvents[i] = o.R.ReputationEvents[ln-1]
+			}
+			o.R.ReputationEvents = o.R.ReputationEvents[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Comments retrieves all the records using an executor.
 func Comments(mods ...qm.QueryMod) commentQuery {
 	mods = append(mods, qm.From("\"comments\""))
@@ -23,51 +23,58 @@ import (
 
 // ErrorLog is an object representing the database table.
 type ErrorLog struct {
-	LogID       int       `boil:"log_id" json:"log_id" toml:"log_id" yaml:"log_id"`
-	ErrMessage  string    `boil:"err_message" json:"err_message" toml:"err_message" yaml:"err_message"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ErrorOrigin string    `boil:"error_origin" json:"error_origin" toml:"error_origin" yaml:"error_origin"`
+	LogID         int       `boil:"log_id" json:"log_id" toml:"log_id" yaml:"log_id"`
+	ErrMessage    string    `boil:"err_message" json:"err_message" toml:"err_message" yaml:"err_message"`
+	CreatedAt     time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ErrorOrigin   string    `boil:"error_origin" json:"error_origin" toml:"error_origin" yaml:"error_origin"`
+	CorrelationID string    `boil:"correlation_id" json:"correlation_id" toml:"correlation_id" yaml:"correlation_id"`
 
 	R *errorLogR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L errorLogL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ErrorLogColumns = struct {
-	LogID       string
-	ErrMessage  string
-	CreatedAt   string
-	ErrorOrigin string
+	LogID         string
+	ErrMessage    string
+	CreatedAt     string
+	ErrorOrigin   string
+	CorrelationID string
 }{
-	LogID:       "log_id",
-	ErrMessage:  "err_message",
-	CreatedAt:   "created_at",
-	ErrorOrigin: "error_origin",
+	LogID:         "log_id",
+	ErrMessage:    "err_message",
+	CreatedAt:     "created_at",
+	ErrorOrigin:   "error_origin",
+	CorrelationID: "correlation_id",
 }
 
 var ErrorLogTableColumns = struct {
-	LogID       string
-	ErrMessage  string
-	CreatedAt   string
-	ErrorOrigin string
+	LogID         string
+	ErrMessage    string
+	CreatedAt     string
+	ErrorOrigin   string
+	CorrelationID string
 }{
-	LogID:       "error_log.log_id",
-	ErrMessage:  "error_log.err_message",
-	CreatedAt:   "error_log.created_at",
-	ErrorOrigin: "error_log.error_origin",
+	LogID:         "error_log.log_id",
+	ErrMessage:    "error_log.err_message",
+	CreatedAt:     "error_log.created_at",
+	ErrorOrigin:   "error_log.error_origin",
+	CorrelationID: "error_log.correlation_id",
 }
 
 // Generated where
 
 var ErrorLogWhere = struct {
-	LogID       whereHelperint
-	ErrMessage  whereHelperstring
-	CreatedAt   whereHelpertime_Time
-	ErrorOrigin whereHelperstring
+	LogID         whereHelperint
+	ErrMessage    whereHelperstring
+	CreatedAt     whereHelpertime_Time
+	ErrorOrigin   whereHelperstring
+	CorrelationID whereHelperstring
 }{
-	LogID:       whereHelperint{field: "\"error_log\".\"log_id\""},
-	ErrMessage:  whereHelperstring{field: "\"error_log\".\"err_message\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"error_log\".\"created_at\""},
-	ErrorOrigin: whereHelperstring{field: "\"error_log\".\"error_origin\""},
+	LogID:         whereHelperint{field: "\"error_log\".\"log_id\""},
+	ErrMessage:    whereHelperstring{field: "\"error_log\".\"err_message\""},
+	CreatedAt:     whereHelpertime_Time{field: "\"error_log\".\"created_at\""},
+	ErrorOrigin:   whereHelperstring{field: "\"error_log\".\"error_origin\""},
+	CorrelationID: whereHelperstring{field: "\"error_log\".\"correlation_id\""},
 }
 
 // ErrorLogRels is where relationship names are stored.
@@ -87,8 +94,8 @@ func (*errorLogR) NewStruct() *errorLogR {
 type errorLogL struct{}
 
 var (
-	errorLogAllColumns            = []string{"log_id", "err_message", "created_at", "error_origin"}
-	errorLogColumnsWithoutDefault = []string{"err_message", "created_at", "error_origin"}
+	errorLogAllColumns            = []string{"log_id", "err_message", "created_at", "error_origin", "correlation_id"}
+	errorLogColumnsWithoutDefault = []string{"err_message", "created_at", "error_origin", "correlation_id"}
 	errorLogColumnsWithDefault    = []string{"log_id"}
 	errorLogPrimaryKeyColumns     = []string{"log_id"}
 )
@@ -0,0 +1,1273 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// PostRevision is an object representing the database table.
+type PostRevision struct {
+	RevisionID int       `boil:"revision_id" json:"revision_id" toml:"revision_id" yaml:"revision_id"`
+	PostID     int       `boil:"post_id" json:"post_id" toml:"post_id" yaml:"post_id"`
+	EditorID   int       `boil:"editor_id" json:"editor_id" toml:"editor_id" yaml:"editor_id"`
+	Title      string    `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Subtitle   string    `boil:"subtitle" json:"subtitle" toml:"subtitle" yaml:"subtitle"`
+	PostText   string    `boil:"post_text" json:"post_text" toml:"post_text" yaml:"post_text"`
+	Format     string    `boil:"format" json:"format" toml:"format" yaml:"format"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *postRevisionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L postRevisionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PostRevisionColumns = struct {
+	RevisionID string
+	PostID     string
+	EditorID   string
+	Title      string
+	Subtitle   string
+	PostText   string
+	Format     string
+	CreatedAt  string
+}{
+	RevisionID: "revision_id",
+	PostID:     "post_id",
+	EditorID:   "editor_id",
+	Title:      "title",
+	Subtitle:   "subtitle",
+	PostText:   "post_text",
+	Format:     "format",
+	CreatedAt:  "created_at",
+}
+
+var PostRevisionTableColumns = struct {
+	RevisionID string
+	PostID     string
+	EditorID   string
+	Title      string
+	Subtitle   string
+	PostText   string
+	Format     string
+	CreatedAt  string
+}{
+	RevisionID: "post_revisions.revision_id",
+	PostID:     "post_revisions.post_id",
+	EditorID:   "post_revisions.editor_id",
+	Title:      "post_revisions.title",
+	Subtitle:   "post_revisions.subtitle",
+	PostText:   "post_revisions.post_text",
+	Format:     "post_revisions.format",
+	CreatedAt:  "post_revisions.created_at",
+}
+
+// Generated where
+
+var PostRevisionWhere = struct {
+	RevisionID whereHelperint
+	PostID     whereHelperint
+	EditorID   whereHelperint
+	Title      whereHelperstring
+	Subtitle   whereHelperstring
+	PostText   whereHelperstring
+	Format     whereHelperstring
+	CreatedAt  whereHelpertime_Time
+}{
+	RevisionID: whereHelperint{field: "\"post_revisions\".\"revision_id\""},
+	PostID:     whereHelperint{field: "\"post_revisions\".\"post_id\""},
+	EditorID:   whereHelperint{field: "\"post_revisions\".\"editor_id\""},
+	Title:      whereHelperstring{field: "\"post_revisions\".\"title\""},
+	Subtitle:   whereHelperstring{field: "\"post_revisions\".\"subtitle\""},
+	PostText:   whereHelperstring{field: "\"post_revisions\".\"post_text\""},
+	Format:     whereHelperstring{field: "\"post_revisions\".\"format\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"post_revisions\".\"created_at\""},
+}
+
+// PostRevisionRels is where relationship names are stored.
+var PostRevisionRels = struct {
+	Editor string
+	Post   string
+}{
+	Editor: "Editor",
+	Post:   "Post",
+}
+
+// postRevisionR is where relationships are stored.
+type postRevisionR struct {
+	Editor *User `boil:"Editor" json:"Editor" toml:"Editor" yaml:"Editor"`
+	Post   *Post `boil:"Post" json:"Post" toml:"Post" yaml:"Post"`
+}
+
+// NewStruct creates a new relationship struct
+func (*postRevisionR) NewStruct() *postRevisionR {
+	return &postRevisionR{}
+}
+
+// postRevisionL is where Load methods for each relationship are stored.
+type postRevisionL struct{}
+
+var (
+	postRevisionAllColumns            = []string{"revision_id", "post_id", "editor_id", "title", "subtitle", "post_text", "format", "created_at"}
+	postRevisionColumnsWithoutDefault = []string{"post_id", "editor_id", "title", "subtitle", "post_text", "created_at"}
+	postRevisionColumnsWithDefault    = []string{"revision_id", "format"}
+	postRevisionPrimaryKeyColumns     = []string{"revision_id"}
+)
+
+type (
+	// PostRevisionSlice is an alias for a slice of pointers to PostRevision.
+	// This should almost always be used instead of []PostRevision.
+	PostRevisionSlice []*PostRevision
+	// PostRevisionHook is the signature for custom PostRevision hook methods
+	PostRevisionHook func(context.Context, boil.ContextExecutor, *PostRevision) error
+
+	postRevisionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	postRevisionType                 = reflect.TypeOf(&PostRevision{})
+	postRevisionMapping              = queries.MakeStructMapping(postRevisionType)
+	postRevisionPrimaryKeyMapping, _ = queries.BindMapping(postRevisionType, postRevisionMapping, postRevisionPrimaryKeyColumns)
+	postRevisionInsertCacheMut       sync.RWMutex
+	postRevisionInsertCache          = make(map[string]insertCache)
+	postRevisionUpdateCacheMut       sync.RWMutex
+	postRevisionUpdateCache          = make(map[string]updateCache)
+	postRevisionUpsertCacheMut       sync.RWMutex
+	postRevisionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var postRevisionBeforeInsertHooks []PostRevisionHook
+var postRevisionBeforeUpdateHooks []PostRevisionHook
+var postRevisionBeforeDeleteHooks []PostRevisionHook
+var postRevisionBeforeUpsertHooks []PostRevisionHook
+
+var postRevisionAfterInsertHooks []PostRevisionHook
+var postRevisionAfterSelectHooks []PostRevisionHook
+var postRevisionAfterUpdateHooks []PostRevisionHook
+var postRevisionAfterDeleteHooks []PostRevisionHook
+var postRevisionAfterUpsertHooks []PostRevisionHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PostRevision) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PostRevision) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PostRevision) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PostRevision) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PostRevision) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PostRevision) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PostRevision) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PostRevision) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PostRevision) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range postRevisionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPostRevisionHook registers your hook function for all future operations.
+func AddPostRevisionHook(hookPoint boil.HookPoint, postRevisionHook PostRevisionHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		postRevisionBeforeInsertHooks = append(postRevisionBeforeInsertHooks, postRevisionHook)
+	case boil.BeforeUpdateHook:
+		postRevisionBeforeUpdateHooks = append(postRevisionBeforeUpdateHooks, postRevisionHook)
+	case boil.BeforeDeleteHook:
+		postRevisionBeforeDeleteHooks = append(postRevisionBeforeDeleteHooks, postRevisionHook)
+	case boil.BeforeUpsertHook:
+		postRevisionBeforeUpsertHooks = append(postRevisionBeforeUpsertHooks, postRevisionHook)
+	case boil.AfterInsertHook:
+		postRevisionAfterInsertHooks = append(postRevisionAfterInsertHooks, postRevisionHook)
+	case boil.AfterSelectHook:
+		postRevisionAfterSelectHooks = append(postRevisionAfterSelectHooks, postRevisionHook)
+	case boil.AfterUpdateHook:
+		postRevisionAfterUpdateHooks = append(postRevisionAfterUpdateHooks, postRevisionHook)
+	case boil.AfterDeleteHook:
+		postRevisionAfterDeleteHooks = append(postRevisionAfterDeleteHooks, postRevisionHook)
+	case boil.AfterUpsertHook:
+		postRevisionAfterUpsertHooks = append(postRevisionAfterUpsertHooks, postRevisionHook)
+	}
+}
+
+// One returns a single postRevision record from the query.
+func (q postRevisionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PostRevision, error) {
+	o := &PostRevision{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for post_revisions")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PostRevision records from the query.
+func (q postRevisionQuery) All(ctx context.Context, exec boil.ContextExecutor) (PostRevisionSlice, error) {
+	var o []*PostRevision
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PostRevision slice")
+	}
+
+	if len(postRevisionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PostRevision records in the query.
+func (q postRevisionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count post_revisions rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q postRevisionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if post_revisions exists")
+	}
+
+	return count > 0, nil
+}
+
+// Editor pointed to by the foreign key.
+func (o *PostRevision) Editor(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"user_id\" = ?", o.EditorID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// Post pointed to by the foreign key.
+func (o *PostRevision) Post(mods ...qm.QueryMod) postQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"post_id\" = ?", o.PostID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Posts(queryMods...)
+	queries.SetFrom(query.Query, "\"posts\"")
+
+	return query
+}
+
+// LoadEditor allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (postRevisionL) LoadEditor(ctx context.Context, e boil.ContextExecutor, singular bool, maybePostRevision interface{}, mods queries.Applicator) error {
+	var slice []*PostRevision
+	var object *PostRevision
+
+	if singular {
+		object = maybePostRevision.(*PostRevision)
+	} else {
+		slice = *maybePostRevision.(*[]*PostRevision)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postRevisionR{}
+		}
+		args = append(args, object.EditorID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postRevisionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.EditorID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.EditorID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(postRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Editor = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.EditorPostRevisions = append(foreign.R.EditorPostRevisions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.EditorID == foreign.UserID {
+				local.R.Editor = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.EditorPostRevisions = append(foreign.R.EditorPostRevisions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPost allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (postRevisionL) LoadPost(ctx context.Context, e boil.ContextExecutor, singular bool, maybePostRevision interface{}, mods queries.Applicator) error {
+	var slice []*PostRevision
+	var object *PostRevision
+
+	if singular {
+		object = maybePostRevision.(*PostRevision)
+	} else {
+		slice = *maybePostRevision.(*[]*PostRevision)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postRevisionR{}
+		}
+		args = append(args, object.PostID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postRevisionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PostID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PostID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`posts`),
+		qm.WhereIn(`posts.post_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Post")
+	}
+
+	var resultSlice []*Post
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Post")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for posts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for posts")
+	}
+
+	if len(postRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Post = foreign
+		if foreign.R == nil {
+			foreign.R = &postR{}
+		}
+		foreign.R.PostRevisions = append(foreign.R.PostRevisions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PostID == foreign.PostID {
+				local.R.Post = foreign
+				if foreign.R == nil {
+					foreign.R = &postR{}
+				}
+				foreign.R.PostRevisions = append(foreign.R.PostRevisions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetEditor of the postRevision to the related item.
+// Sets o.R.Editor to related.
+// Adds o to related.R.EditorPostRevisions.
+func (o *PostRevision) SetEditor(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"post_revisions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"editor_id"}),
+		strmangle.WhereClause("\"", "\"", 2, postRevisionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.UserID, o.RevisionID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.EditorID = related.UserID
+	if o.R == nil {
+		o.R = &postRevisionR{
+			Editor: related,
+		}
+	} else {
+		o.R.Editor = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			EditorPostRevisions: PostRevisionSlice{o},
+		}
+	} else {
+		related.R.EditorPostRevisions = append(related.R.EditorPostRevisions, o)
+	}
+
+	return nil
+}
+
+// SetPost of the postRevision to the related item.
+// Sets o.R.Post to related.
+// Adds o to related.R.PostRevisions.
+func (o *PostRevision) SetPost(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Post) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"post_revisions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+		strmangle.WhereClause("\"", "\"", 2, postRevisionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.PostID, o.RevisionID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PostID = related.PostID
+	if o.R == nil {
+		o.R = &postRevisionR{
+			Post: related,
+		}
+	} else {
+		o.R.Post = related
+	}
+
+	if related.R == nil {
+		related.R = &postR{
+			PostRevisions: PostRevisionSlice{o},
+		}
+	} else {
+		related.R.PostRevisions = append(related.R.PostRevisions, o)
+	}
+
+	return nil
+}
+
+// PostRevisions retrieves all the records using an executor.
+func PostRevisions(mods ...qm.QueryMod) postRevisionQuery {
+	mods = append(mods, qm.From("\"post_revisions\""))
+	return postRevisionQuery{NewQuery(mods...)}
+}
+
+// FindPostRevision retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPostRevision(ctx context.Context, exec boil.ContextExecutor, revisionID int, selectCols ...string) (*PostRevision, error) {
+	postRevisionObj := &PostRevision{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"post_revisions\" where \"revision_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, revisionID)
+
+	err := q.Bind(ctx, exec, postRevisionObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from post_revisions")
+	}
+
+	if err = postRevisionObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return postRevisionObj, err
+	}
+
+	return postRevisionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PostRevision) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no post_revisions provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(postRevisionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	postRevisionInsertCacheMut.RLock()
+	cache, cached := postRevisionInsertCache[key]
+	postRevisionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			postRevisionAllColumns,
+			postRevisionColumnsWithDefault,
+			postRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(postRevisionType, postRevisionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(postRevisionType, postRevisionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"post_revisions\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"post_revisions\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into post_revisions")
+	}
+
+	if !cached {
+		postRevisionInsertCacheMut.Lock()
+		postRevisionInsertCache[key] = cache
+		postRevisionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PostRevision.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PostRevision) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	postRevisionUpdateCacheMut.RLock()
+	cache, cached := postRevisionUpdateCache[key]
+	postRevisionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			postRevisionAllColumns,
+			postRevisionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update post_revisions, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"post_revisions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, postRevisionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(postRevisionType, postRevisionMapping, append(wl, postRevisionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update post_revisions row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for post_revisions")
+	}
+
+	if !cached {
+		postRevisionUpdateCacheMut.Lock()
+		postRevisionUpdateCache[key] = cache
+		postRevisionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q postRevisionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for post_revisions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for post_revisions")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PostRevisionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), postRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"post_revisions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, postRevisionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in postRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all postRevision")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PostRevision) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no post_revisions provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(postRevisionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	postRevisionUpsertCacheMut.RLock()
+	cache, cached := postRevisionUpsertCache[key]
+	postRevisionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			postRevisionAllColumns,
+			postRevisionColumnsWithDefault,
+			postRevisionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			postRevisionAllColumns,
+			postRevisionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert post_revisions, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(postRevisionPrimaryKeyColumns))
+			copy(conflict, postRevisionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"post_revisions\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(postRevisionType, postRevisionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(postRevisionType, postRevisionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert post_revisions")
+	}
+
+	if !cached {
+		postRevisionUpsertCacheMut.Lock()
+		postRevisionUpsertCache[key] = cache
+		postRevisionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PostRevision record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PostRevision) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PostRevision provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), postRevisionPrimaryKeyMapping)
+	sql := "DELETE FROM \"post_revisions\" WHERE \"revision_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from post_revisions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for post_revisions")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q postRevisionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no postRevisionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from post_revisions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for post_revisions")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PostRevisionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(postRevisionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), postRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"post_revisions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, postRevisionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from postRevision slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for post_revisions")
+	}
+
+	if len(postRevisionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PostRevision) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPostRevision(ctx, exec, o.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PostRevisionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PostRevisionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), postRevisionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"post_revisions\".* FROM \"post_revisions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, postRevisionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PostRevisionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PostRevisionExists checks if the PostRevision row exists.
+func PostRevisionExists(ctx context.Context, exec boil.ContextExecutor, revisionID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"post_revisions\" where \"revision_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, revisionID)
+	}
+	row := exec.QueryRowContext(ctx, sql, revisionID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if post_revisions exists")
+	}
+
+	return exists, nil
+}
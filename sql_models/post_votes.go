@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,44 +24,100 @@ import (
 
 // PostVote is an object representing the database table.
 type PostVote struct {
-	PostID    int `boil:"post_id" json:"post_id" toml:"post_id" yaml:"post_id"`
-	VoteValue int `boil:"vote_value" json:"vote_value" toml:"vote_value" yaml:"vote_value"`
-	UserID    int `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	PostID           int         `boil:"post_id" json:"post_id" toml:"post_id" yaml:"post_id"`
+	VoteValue        int         `boil:"vote_value" json:"vote_value" toml:"vote_value" yaml:"vote_value"`
+	UserID           int         `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt        time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	IPAddress        null.String `boil:"ip_address" json:"ip_address,omitempty" toml:"ip_address" yaml:"ip_address,omitempty"`
+	Fingerprint      null.String `boil:"fingerprint" json:"fingerprint,omitempty" toml:"fingerprint" yaml:"fingerprint,omitempty"`
+	FlaggedAt        null.Time   `boil:"flagged_at" json:"flagged_at,omitempty" toml:"flagged_at" yaml:"flagged_at,omitempty"`
+	FlagReason       null.String `boil:"flag_reason" json:"flag_reason,omitempty" toml:"flag_reason" yaml:"flag_reason,omitempty"`
+	ReinstatedAt     null.Time   `boil:"reinstated_at" json:"reinstated_at,omitempty" toml:"reinstated_at" yaml:"reinstated_at,omitempty"`
+	ReinstatedReason null.String `boil:"reinstated_reason" json:"reinstated_reason,omitempty" toml:"reinstated_reason" yaml:"reinstated_reason,omitempty"`
 
 	R *postVoteR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L postVoteL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var PostVoteColumns = struct {
-	PostID    string
-	VoteValue string
-	UserID    string
+	PostID           string
+	VoteValue        string
+	UserID           string
+	CreatedAt        string
+	UpdatedAt        string
+	IPAddress        string
+	Fingerprint      string
+	FlaggedAt        string
+	FlagReason       string
+	ReinstatedAt     string
+	ReinstatedReason string
 }{
-	PostID:    "post_id",
-	VoteValue: "vote_value",
-	UserID:    "user_id",
+	PostID:           "post_id",
+	VoteValue:        "vote_value",
+	UserID:           "user_id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	IPAddress:        "ip_address",
+	Fingerprint:      "fingerprint",
+	FlaggedAt:        "flagged_at",
+	FlagReason:       "flag_reason",
+	ReinstatedAt:     "reinstated_at",
+	ReinstatedReason: "reinstated_reason",
 }
 
 var PostVoteTableColumns = struct {
-	PostID    string
-	VoteValue string
-	UserID    string
+	PostID           string
+	VoteValue        string
+	UserID           string
+	CreatedAt        string
+	UpdatedAt        string
+	IPAddress        string
+	Fingerprint      string
+	FlaggedAt        string
+	FlagReason       string
+	ReinstatedAt     string
+	ReinstatedReason string
 }{
-	PostID:    "post_votes.post_id",
-	VoteValue: "post_votes.vote_value",
-	UserID:    "post_votes.user_id",
+	PostID:           "post_votes.post_id",
+	VoteValue:        "post_votes.vote_value",
+	UserID:           "post_votes.user_id",
+	CreatedAt:        "post_votes.created_at",
+	UpdatedAt:        "post_votes.updated_at",
+	IPAddress:        "post_votes.ip_address",
+	Fingerprint:      "post_votes.fingerprint",
+	FlaggedAt:        "post_votes.flagged_at",
+	FlagReason:       "post_votes.flag_reason",
+	ReinstatedAt:     "post_votes.reinstated_at",
+	ReinstatedReason: "post_votes.reinstated_reason",
 }
 
 // Generated where
 
 var PostVoteWhere = struct {
-	PostID    whereHelperint
-	VoteValue whereHelperint
-	UserID    whereHelperint
+	PostID           whereHelperint
+	VoteValue        whereHelperint
+	UserID           whereHelperint
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	IPAddress        whereHelpernull_String
+	Fingerprint      whereHelpernull_String
+	FlaggedAt        whereHelpernull_Time
+	FlagReason       whereHelpernull_String
+	ReinstatedAt     whereHelpernull_Time
+	ReinstatedReason whereHelpernull_String
 }{
-	PostID:    whereHelperint{field: "\"post_votes\".\"post_id\""},
-	VoteValue: whereHelperint{field: "\"post_votes\".\"vote_value\""},
-	UserID:    whereHelperint{field: "\"post_votes\".\"user_id\""},
+	PostID:           whereHelperint{field: "\"post_votes\".\"post_id\""},
+	VoteValue:        whereHelperint{field: "\"post_votes\".\"vote_value\""},
+	UserID:           whereHelperint{field: "\"post_votes\".\"user_id\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"post_votes\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"post_votes\".\"updated_at\""},
+	IPAddress:        whereHelpernull_String{field: "\"post_votes\".\"ip_address\""},
+	Fingerprint:      whereHelpernull_String{field: "\"post_votes\".\"fingerprint\""},
+	FlaggedAt:        whereHelpernull_Time{field: "\"post_votes\".\"flagged_at\""},
+	FlagReason:       whereHelpernull_String{field: "\"post_votes\".\"flag_reason\""},
+	ReinstatedAt:     whereHelpernull_Time{field: "\"post_votes\".\"reinstated_at\""},
+	ReinstatedReason: whereHelpernull_String{field: "\"post_votes\".\"reinstated_reason\""},
 }
 
 // PostVoteRels is where relationship names are stored.
@@ -87,9 +144,9 @@ func (*postVoteR) NewStruct() *postVoteR {
 type postVoteL struct{}
 
 var (
-	postVoteAllColumns            = []string{"post_id", "vote_value", "user_id"}
-	postVoteColumnsWithoutDefault = []string{"post_id", "vote_value", "user_id"}
-	postVoteColumnsWithDefault    = []string{}
+	postVoteAllColumns            = []string{"post_id", "vote_value", "user_id", "created_at", "updated_at", "ip_address", "fingerprint", "flagged_at", "flag_reason", "reinstated_at", "reinstated_reason"}
+	postVoteColumnsWithoutDefault = []string{"post_id", "vote_value", "user_id", "ip_address", "fingerprint", "flagged_at", "flag_reason", "reinstated_at", "reinstated_reason"}
+	postVoteColumnsWithDefault    = []string{"created_at", "updated_at"}
 	postVotePrimaryKeyColumns     = []string{"post_id", "user_id"}
 )
 
@@ -742,6 +799,16 @@ func (o *PostVote) Insert(ctx context.Context, exec boil.ContextExecutor, column
 	}
 
 	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
 
 	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
@@ -817,6 +884,12 @@ func (o *PostVote) Insert(ctx context.Context, exec boil.ContextExecutor, column
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
 func (o *PostVote) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
 	var err error
 	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
@@ -947,6 +1020,14 @@ func (o *PostVote) Upsert(ctx context.Context, exec boil.ContextExecutor, update
 	if o == nil {
 		return errors.New("models: no post_votes provided for upsert")
 	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
 
 	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
 		return err
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -26,10 +27,16 @@ type Post struct {
 	PostID    int       `boil:"post_id" json:"post_id" toml:"post_id" yaml:"post_id"`
 	UserID    int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
 	Title     string    `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Slug      string    `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
 	Subtitle  string    `boil:"subtitle" json:"subtitle" toml:"subtitle" yaml:"subtitle"`
 	PostText  string    `boil:"post_text" json:"post_text" toml:"post_text" yaml:"post_text"`
+	Format    string    `boil:"format" json:"format" toml:"format" yaml:"format"`
+	Upvotes   int       `boil:"upvotes" json:"upvotes" toml:"upvotes" yaml:"upvotes"`
+	Downvotes int       `boil:"downvotes" json:"downvotes" toml:"downvotes" yaml:"downvotes"`
 	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	Published bool      `boil:"published" json:"published" toml:"published" yaml:"published"`
+	PublishAt null.Time `boil:"publish_at" json:"publish_at,omitempty" toml:"publish_at" yaml:"publish_at,omitempty"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *postR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L postL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,36 +46,60 @@ var PostColumns = struct {
 	PostID    string
 	UserID    string
 	Title     string
+	Slug      string
 	Subtitle  string
 	PostText  string
+	Format    string
+	Upvotes   string
+	Downvotes string
 	CreatedAt string
 	Published string
+	PublishAt string
+	DeletedAt string
 }{
 	PostID:    "post_id",
 	UserID:    "user_id",
 	Title:     "title",
+	Slug:      "slug",
 	Subtitle:  "subtitle",
 	PostText:  "post_text",
+	Format:    "format",
+	Upvotes:   "upvotes",
+	Downvotes: "downvotes",
 	CreatedAt: "created_at",
 	Published: "published",
+	PublishAt: "publish_at",
+	DeletedAt: "deleted_at",
 }
 
 var PostTableColumns = struct {
 	PostID    string
 	UserID    string
 	Title     string
+	Slug      string
 	Subtitle  string
 	PostText  string
+	Format    string
+	Upvotes   string
+	Downvotes string
 	CreatedAt string
 	Published string
+	PublishAt string
+	DeletedAt string
 }{
 	PostID:    "posts.post_id",
 	UserID:    "posts.user_id",
 	Title:     "posts.title",
+	Slug:      "posts.slug",
 	Subtitle:  "posts.subtitle",
 	PostText:  "posts.post_text",
+	Format:    "posts.format",
+	Upvotes:   "posts.upvotes",
+	Downvotes: "posts.downvotes",
 	CreatedAt: "posts.created_at",
 	Published: "posts.published",
+	PublishAt: "posts.publish_at",
+	DeletedAt: "posts.deleted_at",
 }
 
 // Generated where
@@ -86,36 +117,63 @@ var PostWhere = struct {
 	PostID    whereHelperint
 	UserID    whereHelperint
 	Title     whereHelperstring
+	Slug      whereHelperstring
 	Subtitle  whereHelperstring
 	PostText  whereHelperstring
+	Format    whereHelperstring
+	Upvotes   whereHelperint
+	Downvotes whereHelperint
 	CreatedAt whereHelpertime_Time
 	Published whereHelperbool
+	PublishAt whereHelpernull_Time
+	DeletedAt whereHelpernull_Time
 }{
 	PostID:    whereHelperint{field: "\"posts\".\"post_id\""},
 	UserID:    whereHelperint{field: "\"posts\".\"user_id\""},
 	Title:     whereHelperstring{field: "\"posts\".\"title\""},
+	Slug:      whereHelperstring{field: "\"posts\".\"slug\""},
 	Subtitle:  whereHelperstring{field: "\"posts\".\"subtitle\""},
 	PostText:  whereHelperstring{field: "\"posts\".\"post_text\""},
+	Format:    whereHelperstring{field: "\"posts\".\"format\""},
+	Upvotes:   whereHelperint{field: "\"posts\".\"upvotes\""},
+	Downvotes: whereHelperint{field: "\"posts\".\"downvotes\""},
 	CreatedAt: whereHelpertime_Time{field: "\"posts\".\"created_at\""},
 	Published: whereHelperbool{field: "\"posts\".\"published\""},
+	PublishAt: whereHelpernull_Time{field: "\"posts\".\"publish_at\""},
+	DeletedAt: whereHelpernull_Time{field: "\"posts\".\"deleted_at\""},
 }
 
 // PostRels is where relationship names are stored.
 var PostRels = struct {
-	User      string
-	Comments  string
-	PostVotes string
+	User              string
+	Comments          string
+	PostRankings      string
+	PostRevisions     string
+	PostSlugHistories string
+	Tags              string
+	PostVotes         string
+	ReputationEvents  string
 }{
-	User:      "User",
-	Comments:  "Comments",
-	PostVotes: "PostVotes",
+	User:              "User",
+	Comments:          "Comments",
+	PostRankings:      "PostRankings",
+	PostRevisions:     "PostRevisions",
+	PostSlugHistories: "PostSlugHistories",
+	Tags:              "Tags",
+	PostVotes:         "PostVotes",
+	ReputationEvents:  "ReputationEvents",
 }
 
 // postR is where relationships are stored.
 type postR struct {
-	User      *User         `boil:"User" json:"User" toml:"User" yaml:"User"`
-	Comments  CommentSlice  `boil:"Comments" json:"Comments" toml:"Comments" yaml:"Comments"`
-	PostVotes PostVoteSlice `boil:"PostVotes" json:"PostVotes" toml:"PostVotes" yaml:"PostVotes"`
+	User              *User                `boil:"User" json:"User" toml:"User" yaml:"User"`
+	Comments          CommentSlice         `boil:"Comments" json:"Comments" toml:"Comments" yaml:"Comments"`
+	PostRankings      PostRankingSlice     `boil:"PostRankings" json:"PostRankings" toml:"PostRankings" yaml:"PostRankings"`
+	PostRevisions     PostRevisionSlice    `boil:"PostRevisions" json:"PostRevisions" toml:"PostRevisions" yaml:"PostRevisions"`
+	PostSlugHistories PostSlugHistorySlice `boil:"PostSlugHistories" json:"PostSlugHistories" toml:"PostSlugHistories" yaml:"PostSlugHistories"`
+	Tags              TagSlice             `boil:"Tags" json:"Tags" toml:"Tags" yaml:"Tags"`
+	PostVotes         PostVoteSlice        `boil:"PostVotes" json:"PostVotes" toml:"PostVotes" yaml:"PostVotes"`
+	ReputationEvents  ReputationEventSlice `boil:"ReputationEvents" json:"ReputationEvents" toml:"ReputationEvents" yaml:"ReputationEvents"`
 }
 
 // NewStruct creates a new relationship struct
@@ -127,9 +185,9 @@ func (*postR) NewStruct() *postR {
 type postL struct{}
 
 var (
-	postAllColumns            = []string{"post_id", "user_id", "title", "subtitle", "post_text", "created_at", "published"}
-	postColumnsWithoutDefault = []string{"user_id", "title", "subtitle", "post_text", "created_at"}
-	postColumnsWithDefault    = []string{"post_id", "published"}
+	postAllColumns            = []string{"post_id", "user_id", "title", "slug", "subtitle", "post_text", "format", "upvotes", "downvotes", "created_at", "published", "publish_at", "deleted_at"}
+	postColumnsWithoutDefault = []string{"user_id", "title", "slug", "subtitle", "post_text", "created_at", "publish_at", "deleted_at"}
+	postColumnsWithDefault    = []string{"post_id", "format", "upvotes", "downvotes", "published"}
 	postPrimaryKeyColumns     = []string{"post_id"}
 )
 
@@ -443,6 +501,91 @@ func (o *Post) Comments(mods ...qm.QueryMod) commentQuery {
 	return query
 }
 
+// PostRankings retrieves all the post_ranking's PostRankings with an executor.
+func (o *Post) PostRankings(mods ...qm.QueryMod) postRankingQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"post_rankings\".\"post_id\"=?", o.PostID),
+	)
+
+	query := PostRankings(queryMods...)
+	queries.SetFrom(query.Query, "\"post_rankings\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"post_rankings\".*"})
+	}
+
+	return query
+}
+
+// PostRevisions retrieves all the post_revision's PostRevisions with an executor.
+func (o *Post) PostRevisions(mods ...qm.QueryMod) postRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"post_revisions\".\"post_id\"=?", o.PostID),
+	)
+
+	query := PostRevisions(queryMods...)
+	queries.SetFrom(query.Query, "\"post_revisions\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"post_revisions\".*"})
+	}
+
+	return query
+}
+
+// PostSlugHistories retrieves all the post_slug_history's PostSlugHistories with an executor.
+func (o *Post) PostSlugHistories(mods ...qm.QueryMod) postSlugHistoryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"post_slug_history\".\"post_id\"=?", o.PostID),
+	)
+
+	query := PostSlugHistories(queryMods...)
+	queries.SetFrom(query.Query, "\"post_slug_history\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"post_slug_history\".*"})
+	}
+
+	return query
+}
+
+// Tags retrieves all the tag's Tags with an executor.
+func (o *Post) Tags(mods ...qm.QueryMod) tagQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"post_tags\" on \"tags\".\"tag_id\" = \"post_tags\".\"tag_id\""),
+		qm.Where("\"post_tags\".\"post_id\"=?", o.PostID),
+	)
+
+	query := Tags(queryMods...)
+	queries.SetFrom(query.Query, "\"tags\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"tags\".*"})
+	}
+
+	return query
+}
+
 // PostVotes retrieves all the post_vote's PostVotes with an executor.
 func (o *Post) PostVotes(mods ...qm.QueryMod) postVoteQuery {
 	var queryMods []qm.QueryMod
@@ -464,6 +607,27 @@ func (o *Post) PostVotes(mods ...qm.QueryMod) postVoteQuery {
 	return query
 }
 
+// ReputationEvents retrieves all the reputation_event's ReputationEvents with an executor.
+func (o *Post) ReputationEvents(mods ...qm.QueryMod) reputationEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"reputation_events\".\"post_id\"=?", o.PostID),
+	)
+
+	query := ReputationEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"reputation_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"reputation_events\".*"})
+	}
+
+	return query
+}
+
 // LoadUser allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (postL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
@@ -666,9 +830,9 @@ func (postL) LoadComments(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadPostVotes allows an eager lookup of values, cached into the
+// LoadPostRankings allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+func (postL) LoadPostRankings(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
 	var slice []*Post
 	var object *Post
 
@@ -706,8 +870,8 @@ func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`post_votes`),
-		qm.WhereIn(`post_votes.post_id in ?`, args...),
+		qm.From(`post_rankings`),
+		qm.WhereIn(`post_rankings.post_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -715,22 +879,22 @@ func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load post_votes")
+		return errors.Wrap(err, "failed to eager load post_rankings")
 	}
 
-	var resultSlice []*PostVote
+	var resultSlice []*PostRanking
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice post_votes")
+		return errors.Wrap(err, "failed to bind eager loaded slice post_rankings")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on post_votes")
+		return errors.Wrap(err, "failed to close results in eager load on post_rankings")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_votes")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_rankings")
 	}
 
-	if len(postVoteAfterSelectHooks) != 0 {
+	if len(postRankingAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -738,10 +902,10 @@ func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 		}
 	}
 	if singular {
-		object.R.PostVotes = resultSlice
+		object.R.PostRankings = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &postVoteR{}
+				foreign.R = &postRankingR{}
 			}
 			foreign.R.Post = object
 		}
@@ -751,9 +915,9 @@ func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.PostID == foreign.PostID {
-				local.R.PostVotes = append(local.R.PostVotes, foreign)
+				local.R.PostRankings = append(local.R.PostRankings, foreign)
 				if foreign.R == nil {
-					foreign.R = &postVoteR{}
+					foreign.R = &postRankingR{}
 				}
 				foreign.R.Post = local
 				break
@@ -764,110 +928,920 @@ func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// SetUser of the post to the related item.
-// Sets o.R.User to related.
-// Adds o to related.R.Posts.
-func (o *Post) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+// LoadPostRevisions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (postL) LoadPostRevisions(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+	var slice []*Post
+	var object *Post
+
+	if singular {
+		object = maybePost.(*Post)
+	} else {
+		slice = *maybePost.(*[]*Post)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postR{}
+		}
+		args = append(args, object.PostID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PostID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PostID)
 		}
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"posts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-		strmangle.WhereClause("\"", "\"", 2, postPrimaryKeyColumns),
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`post_revisions`),
+		qm.WhereIn(`post_revisions.post_id in ?`, args...),
 	)
-	values := []interface{}{related.UserID, o.PostID}
+	if mods != nil {
+		mods.Apply(query)
+	}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load post_revisions")
 	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+
+	var resultSlice []*PostRevision
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice post_revisions")
 	}
 
-	o.UserID = related.UserID
-	if o.R == nil {
-		o.R = &postR{
-			User: related,
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on post_revisions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_revisions")
+	}
+
+	if len(postRevisionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
 		}
-	} else {
-		o.R.User = related
+	}
+	if singular {
+		object.R.PostRevisions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &postRevisionR{}
+			}
+			foreign.R.Post = object
+		}
+		return nil
 	}
 
-	if related.R == nil {
-		related.R = &userR{
-			Posts: PostSlice{o},
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.PostID == foreign.PostID {
+				local.R.PostRevisions = append(local.R.PostRevisions, foreign)
+				if foreign.R == nil {
+					foreign.R = &postRevisionR{}
+				}
+				foreign.R.Post = local
+				break
+			}
 		}
-	} else {
-		related.R.Posts = append(related.R.Posts, o)
 	}
 
 	return nil
 }
 
-// AddComments adds the given related objects to the existing relationships
-// of the post, optionally inserting them as new records.
-// Appends related to o.R.Comments.
-// Sets related.R.Post appropriately.
-func (o *Post) AddComments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Comment) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.PostID = o.PostID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
-			}
-		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"comments\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
-				strmangle.WhereClause("\"", "\"", 2, commentPrimaryKeyColumns),
-			)
-			values := []interface{}{o.PostID, rel.CommentID}
-
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
-			}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-				return errors.Wrap(err, "failed to update foreign table")
-			}
+// LoadPostSlugHistories allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (postL) LoadPostSlugHistories(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+	var slice []*Post
+	var object *Post
 
-			rel.PostID = o.PostID
-		}
+	if singular {
+		object = maybePost.(*Post)
+	} else {
+		slice = *maybePost.(*[]*Post)
 	}
 
-	if o.R == nil {
-		o.R = &postR{
-			Comments: related,
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postR{}
 		}
+		args = append(args, object.PostID)
 	} else {
-		o.R.Comments = append(o.R.Comments, related...)
-	}
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postR{}
+			}
 
-	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &commentR{
-				Post: o,
+			for _, a := range args {
+				if a == obj.PostID {
+					continue Outer
+				}
 			}
-		} else {
-			rel.R.Post = o
+
+			args = append(args, obj.PostID)
 		}
 	}
-	return nil
-}
 
-// AddPostVotes adds the given related objects to the existing relationships
-// of the post, optionally inserting them as new records.
-// Appends related to o.R.PostVotes.
-// Sets related.R.Post appropriately.
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`post_slug_history`),
+		qm.WhereIn(`post_slug_history.post_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load post_slug_history")
+	}
+
+	var resultSlice []*PostSlugHistory
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice post_slug_history")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on post_slug_history")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_slug_history")
+	}
+
+	if len(postSlugHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PostSlugHistories = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &postSlugHistoryR{}
+			}
+			foreign.R.Post = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.PostID == foreign.PostID {
+				local.R.PostSlugHistories = append(local.R.PostSlugHistories, foreign)
+				if foreign.R == nil {
+					foreign.R = &postSlugHistoryR{}
+				}
+				foreign.R.Post = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTags allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (postL) LoadTags(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+	var slice []*Post
+	var object *Post
+
+	if singular {
+		object = maybePost.(*Post)
+	} else {
+		slice = *maybePost.(*[]*Post)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postR{}
+		}
+		args = append(args, object.PostID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PostID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PostID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"tags\".tag_id, \"tags\".tag_name, \"a\".\"post_id\""),
+		qm.From("\"tags\""),
+		qm.InnerJoin("\"post_tags\" as \"a\" on \"tags\".\"tag_id\" = \"a\".\"tag_id\""),
+		qm.WhereIn("\"a\".\"post_id\" in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tags")
+	}
+
+	var resultSlice []*Tag
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Tag)
+		var localJoinCol int
+
+		err = results.Scan(&one.TagID, &one.TagName, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for tags")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice tags")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tags")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tags")
+	}
+
+	if len(tagAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Tags = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tagR{}
+			}
+			foreign.R.Posts = append(foreign.R.Posts, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.PostID == localJoinCol {
+				local.R.Tags = append(local.R.Tags, foreign)
+				if foreign.R == nil {
+					foreign.R = &tagR{}
+				}
+				foreign.R.Posts = append(foreign.R.Posts, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPostVotes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (postL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+	var slice []*Post
+	var object *Post
+
+	if singular {
+		object = maybePost.(*Post)
+	} else {
+		slice = *maybePost.(*[]*Post)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postR{}
+		}
+		args = append(args, object.PostID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postR{}
+			}
+
+			for _, a := range args {
+				if a == obj.PostID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PostID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`post_votes`),
+		qm.WhereIn(`post_votes.post_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load post_votes")
+	}
+
+	var resultSlice []*PostVote
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice post_votes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on post_votes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_votes")
+	}
+
+	if len(postVoteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PostVotes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &postVoteR{}
+			}
+			foreign.R.Post = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.PostID == foreign.PostID {
+				local.R.PostVotes = append(local.R.PostVotes, foreign)
+				if foreign.R == nil {
+					foreign.R = &postVoteR{}
+				}
+				foreign.R.Post = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadReputationEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (postL) LoadReputationEvents(ctx context.Context, e boil.ContextExecutor, singular bool, maybePost interface{}, mods queries.Applicator) error {
+	var slice []*Post
+	var object *Post
+
+	if singular {
+		object = maybePost.(*Post)
+	} else {
+		slice = *maybePost.(*[]*Post)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &postR{}
+		}
+		args = append(args, object.PostID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &postR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.PostID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.PostID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`reputation_events`),
+		qm.WhereIn(`reputation_events.post_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load reputation_events")
+	}
+
+	var resultSlice []*ReputationEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice reputation_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on reputation_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for reputation_events")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ReputationEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &reputationEventR{}
+			}
+			foreign.R.Post = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.PostID, foreign.PostID) {
+				local.R.ReputationEvents = append(local.R.ReputationEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &reputationEventR{}
+				}
+				foreign.R.Post = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the post to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.Posts.
+func (o *Post) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"posts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, postPrimaryKeyColumns),
+	)
+	values := []interface{}{related.UserID, o.PostID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.UserID
+	if o.R == nil {
+		o.R = &postR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			Posts: PostSlice{o},
+		}
+	} else {
+		related.R.Posts = append(related.R.Posts, o)
+	}
+
+	return nil
+}
+
+// AddComments adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.Comments.
+// Sets related.R.Post appropriately.
+func (o *Post) AddComments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Comment) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PostID = o.PostID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"comments\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+				strmangle.WhereClause("\"", "\"", 2, commentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.PostID, rel.CommentID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PostID = o.PostID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &postR{
+			Comments: related,
+		}
+	} else {
+		o.R.Comments = append(o.R.Comments, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &commentR{
+				Post: o,
+			}
+		} else {
+			rel.R.Post = o
+		}
+	}
+	return nil
+}
+
+// AddPostRankings adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.PostRankings.
+// Sets related.R.Post appropriately.
+func (o *Post) AddPostRankings(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostRanking) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PostID = o.PostID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"post_rankings\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+				strmangle.WhereClause("\"", "\"", 2, postRankingPrimaryKeyColumns),
+			)
+			values := []interface{}{o.PostID, rel.PostID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PostID = o.PostID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &postR{
+			PostRankings: related,
+		}
+	} else {
+		o.R.PostRankings = append(o.R.PostRankings, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &postRankingR{
+				Post: o,
+			}
+		} else {
+			rel.R.Post = o
+		}
+	}
+	return nil
+}
+
+// AddPostRevisions adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.PostRevisions.
+// Sets related.R.Post appropriately.
+func (o *Post) AddPostRevisions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PostID = o.PostID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"post_revisions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+				strmangle.WhereClause("\"", "\"", 2, postRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.PostID, rel.RevisionID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PostID = o.PostID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &postR{
+			PostRevisions: related,
+		}
+	} else {
+		o.R.PostRevisions = append(o.R.PostRevisions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &postRevisionR{
+				Post: o,
+			}
+		} else {
+			rel.R.Post = o
+		}
+	}
+	return nil
+}
+
+// AddPostSlugHistories adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.PostSlugHistories.
+// Sets related.R.Post appropriately.
+func (o *Post) AddPostSlugHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostSlugHistory) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PostID = o.PostID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"post_slug_history\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+				strmangle.WhereClause("\"", "\"", 2, postSlugHistoryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.PostID, rel.UserID, rel.Slug}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PostID = o.PostID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &postR{
+			PostSlugHistories: related,
+		}
+	} else {
+		o.R.PostSlugHistories = append(o.R.PostSlugHistories, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &postSlugHistoryR{
+				Post: o,
+			}
+		} else {
+			rel.R.Post = o
+		}
+	}
+	return nil
+}
+
+// AddTags adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.Tags.
+// Sets related.R.Posts appropriately.
+func (o *Post) AddTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"post_tags\" (\"post_id\", \"tag_id\") values ($1, $2)"
+		values := []interface{}{o.PostID, rel.TagID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &postR{
+			Tags: related,
+		}
+	} else {
+		o.R.Tags = append(o.R.Tags, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tagR{
+				Posts: PostSlice{o},
+			}
+		} else {
+			rel.R.Posts = append(rel.R.Posts, o)
+		}
+	}
+	return nil
+}
+
+// SetTags removes all previously related items of the
+// post replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Posts's Tags accordingly.
+// Replaces o.R.Tags with related.
+// Sets related.R.Posts's Tags accordingly.
+func (o *Post) SetTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
+	query := "delete from \"post_tags\" where \"post_id\" = $1"
+	values := []interface{}{o.PostID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeTagsFromPostsSlice(o, related)
+	if o.R != nil {
+		o.R.Tags = nil
+	}
+	return o.AddTags(ctx, exec, insert, related...)
+}
+
+// RemoveTags relationships from objects passed in.
+// Removes related items from R.Tags (uses pointer comparison, removal does not keep order)
+// Sets related.R.Posts.
+func (o *Post) RemoveTags(ctx context.Context, exec boil.ContextExecutor, related ...*Tag) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"post_tags\" where \"post_id\" = $1 and \"tag_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.PostID}
+	for _, rel := range related {
+		values = append(values, rel.TagID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeTagsFromPostsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Tags {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Tags)
+			if ln > 1 && i < ln-1 {
+				o.R.Tags[i] = o.R.Tags[ln-1]
+			}
+			o.R.Tags = o.R.Tags[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeTagsFromPostsSlice(o *Post, related []*Tag) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Posts {
+			if o.PostID != ri.PostID {
+				continue
+			}
+
+			ln := len(rel.R.Posts)
+			if ln > 1 && i < ln-1 {
+				rel.R.Posts[i] = rel.R.Posts[ln-1]
+			}
+			rel.R.Posts = rel.R.Posts[:ln-1]
+			break
+		}
+	}
+}
+
+// AddPostVotes adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.PostVotes.
+// Sets related.R.Post appropriately.
 func (o *Post) AddPostVotes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostVote) error {
 	var err error
 	for _, rel := range related {
@@ -917,6 +1891,133 @@ func (o *Post) AddPostVotes(ctx context.Context, exec boil.ContextExecutor, inse
 	return nil
 }
 
+// AddReputationEvents adds the given related objects to the existing relationships
+// of the post, optionally inserting them as new records.
+// Appends related to o.R.ReputationEvents.
+// Sets related.R.Post appropriately.
+func (o *Post) AddReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.PostID, o.PostID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"reputation_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+				strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.PostID, rel.EventID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.PostID, o.PostID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &postR{
+			ReputationEvents: related,
+		}
+	} else {
+		o.R.ReputationEvents = append(o.R.ReputationEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &reputationEventR{
+				Post: o,
+			}
+		} else {
+			rel.R.Post = o
+		}
+	}
+	return nil
+}
+
+// SetReputationEvents removes all previously related items of the
+// post replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Post's ReputationEvents accordingly.
+// Replaces o.R.ReputationEvents with related.
+// Sets related.R.Post's ReputationEvents accordingly.
+func (o *Post) SetReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	query := "update \"reputation_events\" set \"post_id\" = null where \"post_id\" = $1"
+	values := []interface{}{o.PostID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ReputationEvents {
+			queries.SetScanner(&rel.PostID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Post = nil
+		}
+
+		o.R.ReputationEvents = nil
+	}
+	return o.AddReputationEvents(ctx, exec, insert, related...)
+}
+
+// RemoveReputationEvents relationships from objects passed in.
+// Removes related items from R.ReputationEvents (uses pointer comparison, removal does not keep order)
+// Sets related.R.Post.
+func (o *Post) RemoveReputationEvents(ctx context.Context, exec boil.ContextExecutor, related ...*ReputationEvent) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.PostID, nil)
+		if rel.R != nil {
+			rel.R.Post = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("post_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ReputationEvents {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ReputationEvents)
+			if ln > 1 && i < ln-1 {
+				o.R.ReputationEvents[i] = o.R.ReputationEvents[ln-1]
+			}
+			o.R.ReputationEvents = o.R.ReputationEvents[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Posts retrieves all the records using an executor.
 func Posts(mods ...qm.QueryMod) postQuery {
 	mods = append(mods, qm.From("\"posts\""))
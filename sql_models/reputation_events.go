@@ -0,0 +1,1677 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ReputationEvent is an object representing the database table.
+type ReputationEvent struct {
+	EventID   int       `boil:"event_id" json:"event_id" toml:"event_id" yaml:"event_id"`
+	UserID    int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	VoterID   int       `boil:"voter_id" json:"voter_id" toml:"voter_id" yaml:"voter_id"`
+	PostID    null.Int  `boil:"post_id" json:"post_id,omitempty" toml:"post_id" yaml:"post_id,omitempty"`
+	CommentID null.Int  `boil:"comment_id" json:"comment_id,omitempty" toml:"comment_id" yaml:"comment_id,omitempty"`
+	Points    int       `boil:"points" json:"points" toml:"points" yaml:"points"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *reputationEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L reputationEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ReputationEventColumns = struct {
+	EventID   string
+	UserID    string
+	VoterID   string
+	PostID    string
+	CommentID string
+	Points    string
+	CreatedAt string
+}{
+	EventID:   "event_id",
+	UserID:    "user_id",
+	VoterID:   "voter_id",
+	PostID:    "post_id",
+	CommentID: "comment_id",
+	Points:    "points",
+	CreatedAt: "created_at",
+}
+
+var ReputationEventTableColumns = struct {
+	EventID   string
+	UserID    string
+	VoterID   string
+	PostID    string
+	CommentID string
+	Points    string
+	CreatedAt string
+}{
+	EventID:   "reputation_events.event_id",
+	UserID:    "reputation_events.user_id",
+	VoterID:   "reputation_events.voter_id",
+	PostID:    "reputation_events.post_id",
+	CommentID: "reputation_events.comment_id",
+	Points:    "reputation_events.points",
+	CreatedAt: "reputation_events.created_at",
+}
+
+// Generated where
+
+var ReputationEventWhere = struct {
+	EventID   whereHelperint
+	UserID    whereHelperint
+	VoterID   whereHelperint
+	PostID    whereHelpernull_Int
+	CommentID whereHelpernull_Int
+	Points    whereHelperint
+	CreatedAt whereHelpertime_Time
+}{
+	EventID:   whereHelperint{field: "\"reputation_events\".\"event_id\""},
+	UserID:    whereHelperint{field: "\"reputation_events\".\"user_id\""},
+	VoterID:   whereHelperint{field: "\"reputation_events\".\"voter_id\""},
+	PostID:    whereHelpernull_Int{field: "\"reputation_events\".\"post_id\""},
+	CommentID: whereHelpernull_Int{field: "\"reputation_events\".\"comment_id\""},
+	Points:    whereHelperint{field: "\"reputation_events\".\"points\""},
+	CreatedAt: whereHelpertime_Time{field: "\"reputation_events\".\"created_at\""},
+}
+
+// ReputationEventRels is where relationship names are stored.
+var ReputationEventRels = struct {
+	Comment string
+	Post    string
+	User    string
+	Voter   string
+}{
+	Comment: "Comment",
+	Post:    "Post",
+	User:    "User",
+	Voter:   "Voter",
+}
+
+// reputationEventR is where relationships are stored.
+type reputationEventR struct {
+	Comment *Comment `boil:"Comment" json:"Comment" toml:"Comment" yaml:"Comment"`
+	Post    *Post    `boil:"Post" json:"Post" toml:"Post" yaml:"Post"`
+	User    *User    `boil:"User" json:"User" toml:"User" yaml:"User"`
+	Voter   *User    `boil:"Voter" json:"Voter" toml:"Voter" yaml:"Voter"`
+}
+
+// NewStruct creates a new relationship struct
+func (*reputationEventR) NewStruct() *reputationEventR {
+	return &reputationEventR{}
+}
+
+// reputationEventL is where Load methods for each relationship are stored.
+type reputationEventL struct{}
+
+var (
+	reputationEventAllColumns            = []string{"event_id", "user_id", "voter_id", "post_id", "comment_id", "points", "created_at"}
+	reputationEventColumnsWithoutDefault = []string{"user_id", "voter_id", "post_id", "comment_id", "points", "created_at"}
+	reputationEventColumnsWithDefault    = []string{"event_id"}
+	reputationEventPrimaryKeyColumns     = []string{"event_id"}
+)
+
+type (
+	// ReputationEventSlice is an alias for a slice of pointers to ReputationEvent.
+	// This should almost always be used instead of []ReputationEvent.
+	ReputationEventSlice []*ReputationEvent
+	// ReputationEventHook is the signature for custom ReputationEvent hook methods
+	ReputationEventHook func(context.Context, boil.ContextExecutor, *ReputationEvent) error
+
+	reputationEventQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	reputationEventType                 = reflect.TypeOf(&ReputationEvent{})
+	reputationEventMapping              = queries.MakeStructMapping(reputationEventType)
+	reputationEventPrimaryKeyMapping, _ = queries.BindMapping(reputationEventType, reputationEventMapping, reputationEventPrimaryKeyColumns)
+	reputationEventInsertCacheMut       sync.RWMutex
+	reputationEventInsertCache          = make(map[string]insertCache)
+	reputationEventUpdateCacheMut       sync.RWMutex
+	reputationEventUpdateCache          = make(map[string]updateCache)
+	reputationEventUpsertCacheMut       sync.RWMutex
+	reputationEventUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var reputationEventBeforeInsertHooks []ReputationEventHook
+var reputationEventBeforeUpdateHooks []ReputationEventHook
+var reputationEventBeforeDeleteHooks []ReputationEventHook
+var reputationEventBeforeUpsertHooks []ReputationEventHook
+
+var reputationEventAfterInsertHooks []ReputationEventHook
+var reputationEventAfterSelectHooks []ReputationEventHook
+var reputationEventAfterUpdateHooks []ReputationEventHook
+var reputationEventAfterDeleteHooks []ReputationEventHook
+var reputationEventAfterUpsertHooks []ReputationEventHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ReputationEvent) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ReputationEvent) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ReputationEvent) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ReputationEvent) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ReputationEvent) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ReputationEvent) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ReputationEvent) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ReputationEvent) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ReputationEvent) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range reputationEventAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddReputationEventHook registers your hook function for all future operations.
+func AddReputationEventHook(hookPoint boil.HookPoint, reputationEventHook ReputationEventHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		reputationEventBeforeInsertHooks = append(reputationEventBeforeInsertHooks, reputationEventHook)
+	case boil.BeforeUpdateHook:
+		reputationEventBeforeUpdateHooks = append(reputationEventBeforeUpdateHooks, reputationEventHook)
+	case boil.BeforeDeleteHook:
+		reputationEventBeforeDeleteHooks = append(reputationEventBeforeDeleteHooks, reputationEventHook)
+	case boil.BeforeUpsertHook:
+		reputationEventBeforeUpsertHooks = append(reputationEventBeforeUpsertHooks, reputationEventHook)
+	case boil.AfterInsertHook:
+		reputationEventAfterInsertHooks = append(reputationEventAfterInsertHooks, reputationEventHook)
+	case boil.AfterSelectHook:
+		reputationEventAfterSelectHooks = append(reputationEventAfterSelectHooks, reputationEventHook)
+	case boil.AfterUpdateHook:
+		reputationEventAfterUpdateHooks = append(reputationEventAfterUpdateHooks, reputationEventHook)
+	case boil.AfterDeleteHook:
+		reputationEventAfterDeleteHooks = append(reputationEventAfterDeleteHooks, reputationEventHook)
+	case boil.AfterUpsertHook:
+		reputationEventAfterUpsertHooks = append(reputationEventAfterUpsertHooks, reputationEventHook)
+	}
+}
+
+// One returns a single reputationEvent record from the query.
+func (q reputationEventQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ReputationEvent, error) {
+	o := &ReputationEvent{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for reputation_events")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ReputationEvent records from the query.
+func (q reputationEventQuery) All(ctx context.Context, exec boil.ContextExecutor) (ReputationEventSlice, error) {
+	var o []*ReputationEvent
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ReputationEvent slice")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ReputationEvent records in the query.
+func (q reputationEventQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count reputation_events rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q reputationEventQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if reputation_events exists")
+	}
+
+	return count > 0, nil
+}
+
+// Comment pointed to by the foreign key.
+func (o *ReputationEvent) Comment(mods ...qm.QueryMod) commentQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"comment_id\" = ?", o.CommentID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Comments(queryMods...)
+	queries.SetFrom(query.Query, "\"comments\"")
+
+	return query
+}
+
+// Post pointed to by the foreign key.
+func (o *ReputationEvent) Post(mods ...qm.QueryMod) postQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"post_id\" = ?", o.PostID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Posts(queryMods...)
+	queries.SetFrom(query.Query, "\"posts\"")
+
+	return query
+}
+
+// User pointed to by the foreign key.
+func (o *ReputationEvent) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"user_id\" = ?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// Voter pointed to by the foreign key.
+func (o *ReputationEvent) Voter(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"user_id\" = ?", o.VoterID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// LoadComment allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (reputationEventL) LoadComment(ctx context.Context, e boil.ContextExecutor, singular bool, maybeReputationEvent interface{}, mods queries.Applicator) error {
+	var slice []*ReputationEvent
+	var object *ReputationEvent
+
+	if singular {
+		object = maybeReputationEvent.(*ReputationEvent)
+	} else {
+		slice = *maybeReputationEvent.(*[]*ReputationEvent)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &reputationEventR{}
+		}
+		if !queries.IsNil(object.CommentID) {
+			args = append(args, object.CommentID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &reputationEventR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.CommentID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.CommentID) {
+				args = append(args, obj.CommentID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`comments`),
+		qm.WhereIn(`comments.comment_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Comment")
+	}
+
+	var resultSlice []*Comment
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Comment")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for comments")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for comments")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Comment = foreign
+		if foreign.R == nil {
+			foreign.R = &commentR{}
+		}
+		foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.CommentID, foreign.CommentID) {
+				local.R.Comment = foreign
+				if foreign.R == nil {
+					foreign.R = &commentR{}
+				}
+				foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPost allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (reputationEventL) LoadPost(ctx context.Context, e boil.ContextExecutor, singular bool, maybeReputationEvent interface{}, mods queries.Applicator) error {
+	var slice []*ReputationEvent
+	var object *ReputationEvent
+
+	if singular {
+		object = maybeReputationEvent.(*ReputationEvent)
+	} else {
+		slice = *maybeReputationEvent.(*[]*ReputationEvent)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &reputationEventR{}
+		}
+		if !queries.IsNil(object.PostID) {
+			args = append(args, object.PostID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &reputationEventR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.PostID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.PostID) {
+				args = append(args, obj.PostID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`posts`),
+		qm.WhereIn(`posts.post_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Post")
+	}
+
+	var resultSlice []*Post
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Post")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for posts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for posts")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Post = foreign
+		if foreign.R == nil {
+			foreign.R = &postR{}
+		}
+		foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.PostID, foreign.PostID) {
+				local.R.Post = foreign
+				if foreign.R == nil {
+					foreign.R = &postR{}
+				}
+				foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (reputationEventL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeReputationEvent interface{}, mods queries.Applicator) error {
+	var slice []*ReputationEvent
+	var object *ReputationEvent
+
+	if singular {
+		object = maybeReputationEvent.(*ReputationEvent)
+	} else {
+		slice = *maybeReputationEvent.(*[]*ReputationEvent)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &reputationEventR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &reputationEventR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.UserID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.ReputationEvents = append(foreign.R.ReputationEvents, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVoter allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (reputationEventL) LoadVoter(ctx context.Context, e boil.ContextExecutor, singular bool, maybeReputationEvent interface{}, mods queries.Applicator) error {
+	var slice []*ReputationEvent
+	var object *ReputationEvent
+
+	if singular {
+		object = maybeReputationEvent.(*ReputationEvent)
+	} else {
+		slice = *maybeReputationEvent.(*[]*ReputationEvent)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &reputationEventR{}
+		}
+		args = append(args, object.VoterID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &reputationEventR{}
+			}
+
+			for _, a := range args {
+				if a == obj.VoterID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.VoterID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Voter = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.VoterReputationEvents = append(foreign.R.VoterReputationEvents, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.VoterID == foreign.UserID {
+				local.R.Voter = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.VoterReputationEvents = append(foreign.R.VoterReputationEvents, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetComment of the reputationEvent to the related item.
+// Sets o.R.Comment to related.
+// Adds o to related.R.ReputationEvents.
+func (o *ReputationEvent) SetComment(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Comment) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"reputation_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"comment_id"}),
+		strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+	)
+	values := []interface{}{related.CommentID, o.EventID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.CommentID, related.CommentID)
+	if o.R == nil {
+		o.R = &reputationEventR{
+			Comment: related,
+		}
+	} else {
+		o.R.Comment = related
+	}
+
+	if related.R == nil {
+		related.R = &commentR{
+			ReputationEvents: ReputationEventSlice{o},
+		}
+	} else {
+		related.R.ReputationEvents = append(related.R.ReputationEvents, o)
+	}
+
+	return nil
+}
+
+// RemoveComment relationship.
+// Sets o.R.Comment to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *ReputationEvent) RemoveComment(ctx context.Context, exec boil.ContextExecutor, related *Comment) error {
+	var err error
+
+	queries.SetScanner(&o.CommentID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("comment_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Comment = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ReputationEvents {
+		if queries.Equal(o.CommentID, ri.CommentID) {
+			continue
+		}
+
+		ln := len(related.R.ReputationEvents)
+		if ln > 1 && i < ln-1 {
+			related.R.ReputationEvents[i] = related.R.ReputationEvents[ln-1]
+		}
+		related.R.ReputationEvents = related.R.ReputationEvents[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetPost of the reputationEvent to the related item.
+// Sets o.R.Post to related.
+// Adds o to related.R.ReputationEvents.
+func (o *ReputationEvent) SetPost(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Post) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"reputation_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"post_id"}),
+		strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+	)
+	values := []interface{}{related.PostID, o.EventID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.PostID, related.PostID)
+	if o.R == nil {
+		o.R = &reputationEventR{
+			Post: related,
+		}
+	} else {
+		o.R.Post = related
+	}
+
+	if related.R == nil {
+		related.R = &postR{
+			ReputationEvents: ReputationEventSlice{o},
+		}
+	} else {
+		related.R.ReputationEvents = append(related.R.ReputationEvents, o)
+	}
+
+	return nil
+}
+
+// RemovePost relationship.
+// Sets o.R.Post to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *ReputationEvent) RemovePost(ctx context.Context, exec boil.ContextExecutor, related *Post) error {
+	var err error
+
+	queries.SetScanner(&o.PostID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("post_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Post = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ReputationEvents {
+		if queries.Equal(o.PostID, ri.PostID) {
+			continue
+		}
+
+		ln := len(related.R.ReputationEvents)
+		if ln > 1 && i < ln-1 {
+			related.R.ReputationEvents[i] = related.R.ReputationEvents[ln-1]
+		}
+		related.R.ReputationEvents = related.R.ReputationEvents[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetUser of the reputationEvent to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.ReputationEvents.
+func (o *ReputationEvent) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"reputation_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+	)
+	values := []interface{}{related.UserID, o.EventID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.UserID
+	if o.R == nil {
+		o.R = &reputationEventR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			ReputationEvents: ReputationEventSlice{o},
+		}
+	} else {
+		related.R.ReputationEvents = append(related.R.ReputationEvents, o)
+	}
+
+	return nil
+}
+
+// SetVoter of the reputationEvent to the related item.
+// Sets o.R.Voter to related.
+// Adds o to related.R.VoterReputationEvents.
+func (o *ReputationEvent) SetVoter(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"reputation_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"voter_id"}),
+		strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+	)
+	values := []interface{}{related.UserID, o.EventID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.VoterID = related.UserID
+	if o.R == nil {
+		o.R = &reputationEventR{
+			Voter: related,
+		}
+	} else {
+		o.R.Voter = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			VoterReputationEvents: ReputationEventSlice{o},
+		}
+	} else {
+		related.R.VoterReputationEvents = append(related.R.VoterReputationEvents, o)
+	}
+
+	return nil
+}
+
+// ReputationEvents retrieves all the records using an executor.
+func ReputationEvents(mods ...qm.QueryMod) reputationEventQuery {
+	mods = append(mods, qm.From("\"reputation_events\""))
+	return reputationEventQuery{NewQuery(mods...)}
+}
+
+// FindReputationEvent retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindReputationEvent(ctx context.Context, exec boil.ContextExecutor, eventID int, selectCols ...string) (*ReputationEvent, error) {
+	reputationEventObj := &ReputationEvent{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"reputation_events\" where \"event_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, eventID)
+
+	err := q.Bind(ctx, exec, reputationEventObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from reputation_events")
+	}
+
+	if err = reputationEventObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return reputationEventObj, err
+	}
+
+	return reputationEventObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ReputationEvent) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no reputation_events provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(reputationEventColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	reputationEventInsertCacheMut.RLock()
+	cache, cached := reputationEventInsertCache[key]
+	reputationEventInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			reputationEventAllColumns,
+			reputationEventColumnsWithDefault,
+			reputationEventColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(reputationEventType, reputationEventMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(reputationEventType, reputationEventMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"reputation_events\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"reputation_events\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into reputation_events")
+	}
+
+	if !cached {
+		reputationEventInsertCacheMut.Lock()
+		reputationEventInsertCache[key] = cache
+		reputationEventInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ReputationEvent.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ReputationEvent) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	reputationEventUpdateCacheMut.RLock()
+	cache, cached := reputationEventUpdateCache[key]
+	reputationEventUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			reputationEventAllColumns,
+			reputationEventPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update reputation_events, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"reputation_events\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, reputationEventPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(reputationEventType, reputationEventMapping, append(wl, reputationEventPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update reputation_events row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for reputation_events")
+	}
+
+	if !cached {
+		reputationEventUpdateCacheMut.Lock()
+		reputationEventUpdateCache[key] = cache
+		reputationEventUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q reputationEventQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for reputation_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for reputation_events")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ReputationEventSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), reputationEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"reputation_events\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, reputationEventPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in reputationEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all reputationEvent")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ReputationEvent) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no reputation_events provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(reputationEventColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	reputationEventUpsertCacheMut.RLock()
+	cache, cached := reputationEventUpsertCache[key]
+	reputationEventUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			reputationEventAllColumns,
+			reputationEventColumnsWithDefault,
+			reputationEventColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			reputationEventAllColumns,
+			reputationEventPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert reputation_events, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(reputationEventPrimaryKeyColumns))
+			copy(conflict, reputationEventPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"reputation_events\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(reputationEventType, reputationEventMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(reputationEventType, reputationEventMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert reputation_events")
+	}
+
+	if !cached {
+		reputationEventUpsertCacheMut.Lock()
+		reputationEventUpsertCache[key] = cache
+		reputationEventUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ReputationEvent record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ReputationEvent) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ReputationEvent provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), reputationEventPrimaryKeyMapping)
+	sql := "DELETE FROM \"reputation_events\" WHERE \"event_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from reputation_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for reputation_events")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q reputationEventQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no reputationEventQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from reputation_events")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for reputation_events")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ReputationEventSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(reputationEventBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), reputationEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"reputation_events\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, reputationEventPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from reputationEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for reputation_events")
+	}
+
+	if len(reputationEventAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ReputationEvent) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindReputationEvent(ctx, exec, o.EventID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ReputationEventSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ReputationEventSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), reputationEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"reputation_events\".* FROM \"reputation_events\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, reputationEventPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ReputationEventSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ReputationEventExists checks if the ReputationEvent row exists.
+func ReputationEventExists(ctx context.Context, exec boil.ContextExecutor, eventID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"reputation_events\" where \"event_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, eventID)
+	}
+	row := exec.QueryRowContext(ctx, sql, eventID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if reputation_events exists")
+	}
+
+	return exists, nil
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,86 +24,166 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	UserID       int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	Username     string    `boil:"username" json:"username" toml:"username" yaml:"username"`
-	Email        string    `boil:"email" json:"email" toml:"email" yaml:"email"`
-	UserPassword string    `boil:"user_password" json:"user_password" toml:"user_password" yaml:"user_password"`
-	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	Active       bool      `boil:"active" json:"active" toml:"active" yaml:"active"`
+	UserID          int         `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Username        string      `boil:"username" json:"username" toml:"username" yaml:"username"`
+	Email           string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	UserPassword    string      `boil:"user_password" json:"user_password" toml:"user_password" yaml:"user_password"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Active          bool        `boil:"active" json:"active" toml:"active" yaml:"active"`
+	Reputation      int         `boil:"reputation" json:"reputation" toml:"reputation" yaml:"reputation"`
+	EmailVerifiedAt null.Time   `boil:"email_verified_at" json:"email_verified_at,omitempty" toml:"email_verified_at" yaml:"email_verified_at,omitempty"`
+	TotpSecret      null.String `boil:"totp_secret" json:"totp_secret,omitempty" toml:"totp_secret" yaml:"totp_secret,omitempty"`
+	TotpEnabledAt   null.Time   `boil:"totp_enabled_at" json:"totp_enabled_at,omitempty" toml:"totp_enabled_at" yaml:"totp_enabled_at,omitempty"`
+	TotpLastStep    null.Int64  `boil:"totp_last_step" json:"totp_last_step,omitempty" toml:"totp_last_step" yaml:"totp_last_step,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	UserID       string
-	Username     string
-	Email        string
-	UserPassword string
-	CreatedAt    string
-	Active       string
+	UserID          string
+	Username        string
+	Email           string
+	UserPassword    string
+	CreatedAt       string
+	Active          string
+	Reputation      string
+	EmailVerifiedAt string
+	TotpSecret      string
+	TotpEnabledAt   string
+	TotpLastStep    string
 }{
-	UserID:       "user_id",
-	Username:     "username",
-	Email:        "email",
-	UserPassword: "user_password",
-	CreatedAt:    "created_at",
-	Active:       "active",
+	UserID:          "user_id",
+	Username:        "username",
+	Email:           "email",
+	UserPassword:    "user_password",
+	CreatedAt:       "created_at",
+	Active:          "active",
+	Reputation:      "reputation",
+	EmailVerifiedAt: "email_verified_at",
+	TotpSecret:      "totp_secret",
+	TotpEnabledAt:   "totp_enabled_at",
+	TotpLastStep:    "totp_last_step",
 }
 
 var UserTableColumns = struct {
-	UserID       string
-	Username     string
-	Email        string
-	UserPassword string
-	CreatedAt    string
-	Active       string
+	UserID          string
+	Username        string
+	Email           string
+	UserPassword    string
+	CreatedAt       string
+	Active          string
+	Reputation      string
+	EmailVerifiedAt string
+	TotpSecret      string
+	TotpEnabledAt   string
+	TotpLastStep    string
 }{
-	UserID:       "users.user_id",
-	Username:     "users.username",
-	Email:        "users.email",
-	UserPassword: "users.user_password",
-	CreatedAt:    "users.created_at",
-	Active:       "users.active",
+	UserID:          "users.user_id",
+	Username:        "users.username",
+	Email:           "users.email",
+	UserPassword:    "users.user_password",
+	CreatedAt:       "users.created_at",
+	Active:          "users.active",
+	Reputation:      "users.reputation",
+	EmailVerifiedAt: "users.email_verified_at",
+	TotpSecret:      "users.totp_secret",
+	TotpEnabledAt:   "users.totp_enabled_at",
+	TotpLastStep:    "users.totp_last_step",
 }
 
 // Generated where
 
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var UserWhere = struct {
-	UserID       whereHelperint
-	Username     whereHelperstring
-	Email        whereHelperstring
-	UserPassword whereHelperstring
-	CreatedAt    whereHelpertime_Time
-	Active       whereHelperbool
+	UserID          whereHelperint
+	Username        whereHelperstring
+	Email           whereHelperstring
+	UserPassword    whereHelperstring
+	CreatedAt       whereHelpertime_Time
+	Active          whereHelperbool
+	Reputation      whereHelperint
+	EmailVerifiedAt whereHelpernull_Time
+	TotpSecret      whereHelpernull_String
+	TotpEnabledAt   whereHelpernull_Time
+	TotpLastStep    whereHelpernull_Int64
 }{
-	UserID:       whereHelperint{field: "\"users\".\"user_id\""},
-	Username:     whereHelperstring{field: "\"users\".\"username\""},
-	Email:        whereHelperstring{field: "\"users\".\"email\""},
-	UserPassword: whereHelperstring{field: "\"users\".\"user_password\""},
-	CreatedAt:    whereHelpertime_Time{field: "\"users\".\"created_at\""},
-	Active:       whereHelperbool{field: "\"users\".\"active\""},
+	UserID:          whereHelperint{field: "\"users\".\"user_id\""},
+	Username:        whereHelperstring{field: "\"users\".\"username\""},
+	Email:           whereHelperstring{field: "\"users\".\"email\""},
+	UserPassword:    whereHelperstring{field: "\"users\".\"user_password\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"users\".\"created_at\""},
+	Active:          whereHelperbool{field: "\"users\".\"active\""},
+	Reputation:      whereHelperint{field: "\"users\".\"reputation\""},
+	EmailVerifiedAt: whereHelpernull_Time{field: "\"users\".\"email_verified_at\""},
+	TotpSecret:      whereHelpernull_String{field: "\"users\".\"totp_secret\""},
+	TotpEnabledAt:   whereHelpernull_Time{field: "\"users\".\"totp_enabled_at\""},
+	TotpLastStep:    whereHelpernull_Int64{field: "\"users\".\"totp_last_step\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	CommentVotes string
-	Comments     string
-	PostVotes    string
-	Posts        string
+	APITokens             string
+	CommentVotes          string
+	Comments              string
+	EditorPostRevisions   string
+	PostSlugHistories     string
+	PostVotes             string
+	Posts                 string
+	RecoveryCodes         string
+	ReputationEvents      string
+	VoterReputationEvents string
+	Roles                 string
 }{
-	CommentVotes: "CommentVotes",
-	Comments:     "Comments",
-	PostVotes:    "PostVotes",
-	Posts:        "Posts",
+	APITokens:             "APITokens",
+	CommentVotes:          "CommentVotes",
+	Comments:              "Comments",
+	EditorPostRevisions:   "EditorPostRevisions",
+	PostSlugHistories:     "PostSlugHistories",
+	PostVotes:             "PostVotes",
+	Posts:                 "Posts",
+	RecoveryCodes:         "RecoveryCodes",
+	ReputationEvents:      "ReputationEvents",
+	VoterReputationEvents: "VoterReputationEvents",
+	Roles:                 "Roles",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	CommentVotes CommentVoteSlice `boil:"CommentVotes" json:"CommentVotes" toml:"CommentVotes" yaml:"CommentVotes"`
-	Comments     CommentSlice     `boil:"Comments" json:"Comments" toml:"Comments" yaml:"Comments"`
-	PostVotes    PostVoteSlice    `boil:"PostVotes" json:"PostVotes" toml:"PostVotes" yaml:"PostVotes"`
-	Posts        PostSlice        `boil:"Posts" json:"Posts" toml:"Posts" yaml:"Posts"`
+	APITokens             APITokenSlice        `boil:"APITokens" json:"APITokens" toml:"APITokens" yaml:"APITokens"`
+	CommentVotes          CommentVoteSlice     `boil:"CommentVotes" json:"CommentVotes" toml:"CommentVotes" yaml:"CommentVotes"`
+	Comments              CommentSlice         `boil:"Comments" json:"Comments" toml:"Comments" yaml:"Comments"`
+	EditorPostRevisions   PostRevisionSlice    `boil:"EditorPostRevisions" json:"EditorPostRevisions" toml:"EditorPostRevisions" yaml:"EditorPostRevisions"`
+	PostSlugHistories     PostSlugHistorySlice `boil:"PostSlugHistories" json:"PostSlugHistories" toml:"PostSlugHistories" yaml:"PostSlugHistories"`
+	PostVotes             PostVoteSlice        `boil:"PostVotes" json:"PostVotes" toml:"PostVotes" yaml:"PostVotes"`
+	Posts                 PostSlice            `boil:"Posts" json:"Posts" toml:"Posts" yaml:"Posts"`
+	RecoveryCodes         RecoveryCodeSlice    `boil:"RecoveryCodes" json:"RecoveryCodes" toml:"RecoveryCodes" yaml:"RecoveryCodes"`
+	ReputationEvents      ReputationEventSlice `boil:"ReputationEvents" json:"ReputationEvents" toml:"ReputationEvents" yaml:"ReputationEvents"`
+	VoterReputationEvents ReputationEventSlice `boil:"VoterReputationEvents" json:"VoterReputationEvents" toml:"VoterReputationEvents" yaml:"VoterReputationEvents"`
+	Roles                 RoleSlice            `boil:"Roles" json:"Roles" toml:"Roles" yaml:"Roles"`
 }
 
 // NewStruct creates a new relationship struct
@@ -114,9 +195,9 @@ func (*userR) NewStruct() *userR {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"user_id", "username", "email", "user_password", "created_at", "active"}
-	userColumnsWithoutDefault = []string{"username", "email", "user_password", "created_at"}
-	userColumnsWithDefault    = []string{"user_id", "active"}
+	userAllColumns            = []string{"user_id", "username", "email", "user_password", "created_at", "active", "reputation", "email_verified_at", "totp_secret", "totp_enabled_at", "totp_last_step"}
+	userColumnsWithoutDefault = []string{"username", "email", "user_password", "created_at", "email_verified_at", "totp_secret", "totp_enabled_at", "totp_last_step"}
+	userColumnsWithDefault    = []string{"user_id", "active", "reputation"}
 	userPrimaryKeyColumns     = []string{"user_id"}
 )
 
@@ -395,6 +476,27 @@ func (q userQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
+// APITokens retrieves all the api_token's APITokens with an executor.
+func (o *User) APITokens(mods ...qm.QueryMod) apiTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_tokens\".\"user_id\"=?", o.UserID),
+	)
+
+	query := APITokens(queryMods...)
+	queries.SetFrom(query.Query, "\"api_tokens\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"api_tokens\".*"})
+	}
+
+	return query
+}
+
 // CommentVotes retrieves all the comment_vote's CommentVotes with an executor.
 func (o *User) CommentVotes(mods ...qm.QueryMod) commentVoteQuery {
 	var queryMods []qm.QueryMod
@@ -437,6 +539,48 @@ func (o *User) Comments(mods ...qm.QueryMod) commentQuery {
 	return query
 }
 
+// EditorPostRevisions retrieves all the post_revision's PostRevisions with an executor via editor_id column.
+func (o *User) EditorPostRevisions(mods ...qm.QueryMod) postRevisionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"post_revisions\".\"editor_id\"=?", o.UserID),
+	)
+
+	query := PostRevisions(queryMods...)
+	queries.SetFrom(query.Query, "\"post_revisions\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"post_revisions\".*"})
+	}
+
+	return query
+}
+
+// PostSlugHistories retrieves all the post_slug_history's PostSlugHistories with an executor.
+func (o *User) PostSlugHistories(mods ...qm.QueryMod) postSlugHistoryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"post_slug_history\".\"user_id\"=?", o.UserID),
+	)
+
+	query := PostSlugHistories(queryMods...)
+	queries.SetFrom(query.Query, "\"post_slug_history\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"post_slug_history\".*"})
+	}
+
+	return query
+}
+
 // PostVotes retrieves all the post_vote's PostVotes with an executor.
 func (o *User) PostVotes(mods ...qm.QueryMod) postVoteQuery {
 	var queryMods []qm.QueryMod
@@ -479,6 +623,189 @@ func (o *User) Posts(mods ...qm.QueryMod) postQuery {
 	return query
 }
 
+// RecoveryCodes retrieves all the recovery_code's RecoveryCodes with an executor.
+func (o *User) RecoveryCodes(mods ...qm.QueryMod) recoveryCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"recovery_codes\".\"user_id\"=?", o.UserID),
+	)
+
+	query := RecoveryCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"recovery_codes\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"recovery_codes\".*"})
+	}
+
+	return query
+}
+
+// ReputationEvents retrieves all the reputation_event's ReputationEvents with an executor.
+func (o *User) ReputationEvents(mods ...qm.QueryMod) reputationEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"reputation_events\".\"user_id\"=?", o.UserID),
+	)
+
+	query := ReputationEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"reputation_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"reputation_events\".*"})
+	}
+
+	return query
+}
+
+// VoterReputationEvents retrieves all the reputation_event's ReputationEvents with an executor via voter_id column.
+func (o *User) VoterReputationEvents(mods ...qm.QueryMod) reputationEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"reputation_events\".\"voter_id\"=?", o.UserID),
+	)
+
+	query := ReputationEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"reputation_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"reputation_events\".*"})
+	}
+
+	return query
+}
+
+// Roles retrieves all the role's Roles with an executor.
+func (o *User) Roles(mods ...qm.QueryMod) roleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"user_roles\" on \"roles\".\"role_id\" = \"user_roles\".\"role_id\""),
+		qm.Where("\"user_roles\".\"user_id\"=?", o.UserID),
+	)
+
+	query := Roles(queryMods...)
+	queries.SetFrom(query.Query, "\"roles\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"roles\".*"})
+	}
+
+	return query
+}
+
+// LoadAPITokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadAPITokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`api_tokens`),
+		qm.WhereIn(`api_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_tokens")
+	}
+
+	var resultSlice []*APIToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_tokens")
+	}
+
+	if len(apiTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.APITokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.UserID {
+				local.R.APITokens = append(local.R.APITokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCommentVotes allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userL) LoadCommentVotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
@@ -675,9 +1002,9 @@ func (userL) LoadComments(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadPostVotes allows an eager lookup of values, cached into the
+// LoadEditorPostRevisions allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadEditorPostRevisions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -715,8 +1042,8 @@ func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`post_votes`),
-		qm.WhereIn(`post_votes.user_id in ?`, args...),
+		qm.From(`post_revisions`),
+		qm.WhereIn(`post_revisions.editor_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -724,22 +1051,22 @@ func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load post_votes")
+		return errors.Wrap(err, "failed to eager load post_revisions")
 	}
 
-	var resultSlice []*PostVote
+	var resultSlice []*PostRevision
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice post_votes")
+		return errors.Wrap(err, "failed to bind eager loaded slice post_revisions")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on post_votes")
+		return errors.Wrap(err, "failed to close results in eager load on post_revisions")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_votes")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_revisions")
 	}
 
-	if len(postVoteAfterSelectHooks) != 0 {
+	if len(postRevisionAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -747,24 +1074,24 @@ func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 		}
 	}
 	if singular {
-		object.R.PostVotes = resultSlice
+		object.R.EditorPostRevisions = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &postVoteR{}
+				foreign.R = &postRevisionR{}
 			}
-			foreign.R.User = object
+			foreign.R.Editor = object
 		}
 		return nil
 	}
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.UserID == foreign.UserID {
-				local.R.PostVotes = append(local.R.PostVotes, foreign)
+			if local.UserID == foreign.EditorID {
+				local.R.EditorPostRevisions = append(local.R.EditorPostRevisions, foreign)
 				if foreign.R == nil {
-					foreign.R = &postVoteR{}
+					foreign.R = &postRevisionR{}
 				}
-				foreign.R.User = local
+				foreign.R.Editor = local
 				break
 			}
 		}
@@ -773,9 +1100,9 @@ func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadPosts allows an eager lookup of values, cached into the
+// LoadPostSlugHistories allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadPostSlugHistories(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -813,8 +1140,8 @@ func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular boo
 	}
 
 	query := NewQuery(
-		qm.From(`posts`),
-		qm.WhereIn(`posts.user_id in ?`, args...),
+		qm.From(`post_slug_history`),
+		qm.WhereIn(`post_slug_history.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -822,22 +1149,22 @@ func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular boo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load posts")
+		return errors.Wrap(err, "failed to eager load post_slug_history")
 	}
 
-	var resultSlice []*Post
+	var resultSlice []*PostSlugHistory
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice posts")
+		return errors.Wrap(err, "failed to bind eager loaded slice post_slug_history")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on posts")
+		return errors.Wrap(err, "failed to close results in eager load on post_slug_history")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for posts")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_slug_history")
 	}
 
-	if len(postAfterSelectHooks) != 0 {
+	if len(postSlugHistoryAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -845,10 +1172,10 @@ func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular boo
 		}
 	}
 	if singular {
-		object.R.Posts = resultSlice
+		object.R.PostSlugHistories = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &postR{}
+				foreign.R = &postSlugHistoryR{}
 			}
 			foreign.R.User = object
 		}
@@ -858,9 +1185,9 @@ func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular boo
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.UserID == foreign.UserID {
-				local.R.Posts = append(local.R.Posts, foreign)
+				local.R.PostSlugHistories = append(local.R.PostSlugHistories, foreign)
 				if foreign.R == nil {
-					foreign.R = &postR{}
+					foreign.R = &postSlugHistoryR{}
 				}
 				foreign.R.User = local
 				break
@@ -871,64 +1198,828 @@ func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular boo
 	return nil
 }
 
-// AddCommentVotes adds the given related objects to the existing relationships
-// of the user, optionally inserting them as new records.
-// Appends related to o.R.CommentVotes.
-// Sets related.R.User appropriately.
-func (o *User) AddCommentVotes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CommentVote) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.UserID = o.UserID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
-			}
-		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"comment_votes\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-				strmangle.WhereClause("\"", "\"", 2, commentVotePrimaryKeyColumns),
-			)
-			values := []interface{}{o.UserID, rel.CommentID, rel.UserID}
+// LoadPostVotes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPostVotes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
 
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`post_votes`),
+		qm.WhereIn(`post_votes.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load post_votes")
+	}
+
+	var resultSlice []*PostVote
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice post_votes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on post_votes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for post_votes")
+	}
+
+	if len(postVoteAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PostVotes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &postVoteR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.UserID {
+				local.R.PostVotes = append(local.R.PostVotes, foreign)
+				if foreign.R == nil {
+					foreign.R = &postVoteR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPosts allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPosts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`posts`),
+		qm.WhereIn(`posts.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load posts")
+	}
+
+	var resultSlice []*Post
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice posts")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on posts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for posts")
+	}
+
+	if len(postAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Posts = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &postR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.UserID {
+				local.R.Posts = append(local.R.Posts, foreign)
+				if foreign.R == nil {
+					foreign.R = &postR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRecoveryCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadRecoveryCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`recovery_codes`),
+		qm.WhereIn(`recovery_codes.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load recovery_codes")
+	}
+
+	var resultSlice []*RecoveryCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice recovery_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on recovery_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for recovery_codes")
+	}
+
+	if len(recoveryCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RecoveryCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &recoveryCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.UserID {
+				local.R.RecoveryCodes = append(local.R.RecoveryCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &recoveryCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadReputationEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadReputationEvents(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`reputation_events`),
+		qm.WhereIn(`reputation_events.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load reputation_events")
+	}
+
+	var resultSlice []*ReputationEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice reputation_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on reputation_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for reputation_events")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ReputationEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &reputationEventR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.UserID {
+				local.R.ReputationEvents = append(local.R.ReputationEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &reputationEventR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVoterReputationEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadVoterReputationEvents(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`reputation_events`),
+		qm.WhereIn(`reputation_events.voter_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load reputation_events")
+	}
+
+	var resultSlice []*ReputationEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice reputation_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on reputation_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for reputation_events")
+	}
+
+	if len(reputationEventAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.VoterReputationEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &reputationEventR{}
+			}
+			foreign.R.Voter = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.UserID == foreign.VoterID {
+				local.R.VoterReputationEvents = append(local.R.VoterReputationEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &reputationEventR{}
+				}
+				foreign.R.Voter = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRoles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadRoles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.UserID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"roles\".role_id, \"roles\".role_name, \"a\".\"user_id\""),
+		qm.From("\"roles\""),
+		qm.InnerJoin("\"user_roles\" as \"a\" on \"roles\".\"role_id\" = \"a\".\"role_id\""),
+		qm.WhereIn("\"a\".\"user_id\" in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load roles")
+	}
+
+	var resultSlice []*Role
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Role)
+		var localJoinCol int
+
+		err = results.Scan(&one.RoleID, &one.RoleName, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for roles")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice roles")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on roles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for roles")
+	}
+
+	if len(roleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Roles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &roleR{}
+			}
+			foreign.R.Users = append(foreign.R.Users, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.UserID == localJoinCol {
+				local.R.Roles = append(local.R.Roles, foreign)
+				if foreign.R == nil {
+					foreign.R = &roleR{}
+				}
+				foreign.R.Users = append(foreign.R.Users, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAPITokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APITokens.
+// Sets related.R.User appropriately.
+func (o *User) AddAPITokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.TokenID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APITokens: related,
+		}
+	} else {
+		o.R.APITokens = append(o.R.APITokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddCommentVotes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.CommentVotes.
+// Sets related.R.User appropriately.
+func (o *User) AddCommentVotes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CommentVote) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"comment_votes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, commentVotePrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.CommentID, rel.UserID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			CommentVotes: related,
+		}
+	} else {
+		o.R.CommentVotes = append(o.R.CommentVotes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &commentVoteR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddComments adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Comments.
+// Sets related.R.User appropriately.
+func (o *User) AddComments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Comment) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"comments\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, commentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.CommentID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Comments: related,
+		}
+	} else {
+		o.R.Comments = append(o.R.Comments, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &commentR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddEditorPostRevisions adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.EditorPostRevisions.
+// Sets related.R.Editor appropriately.
+func (o *User) AddEditorPostRevisions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostRevision) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.EditorID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"post_revisions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"editor_id"}),
+				strmangle.WhereClause("\"", "\"", 2, postRevisionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.RevisionID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
 				fmt.Fprintln(writer, values)
 			}
 			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			rel.UserID = o.UserID
+			rel.EditorID = o.UserID
 		}
 	}
 
 	if o.R == nil {
 		o.R = &userR{
-			CommentVotes: related,
+			EditorPostRevisions: related,
 		}
 	} else {
-		o.R.CommentVotes = append(o.R.CommentVotes, related...)
+		o.R.EditorPostRevisions = append(o.R.EditorPostRevisions, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &commentVoteR{
-				User: o,
+			rel.R = &postRevisionR{
+				Editor: o,
 			}
 		} else {
-			rel.R.User = o
+			rel.R.Editor = o
 		}
 	}
 	return nil
 }
 
-// AddComments adds the given related objects to the existing relationships
+// AddPostSlugHistories adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
-// Appends related to o.R.Comments.
+// Appends related to o.R.PostSlugHistories.
 // Sets related.R.User appropriately.
-func (o *User) AddComments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Comment) error {
+func (o *User) AddPostSlugHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PostSlugHistory) error {
 	var err error
 	for _, rel := range related {
 		if insert {
@@ -938,11 +2029,11 @@ func (o *User) AddComments(ctx context.Context, exec boil.ContextExecutor, inser
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"comments\" SET %s WHERE %s",
+				"UPDATE \"post_slug_history\" SET %s WHERE %s",
 				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-				strmangle.WhereClause("\"", "\"", 2, commentPrimaryKeyColumns),
+				strmangle.WhereClause("\"", "\"", 2, postSlugHistoryPrimaryKeyColumns),
 			)
-			values := []interface{}{o.UserID, rel.CommentID}
+			values := []interface{}{o.UserID, rel.UserID, rel.Slug}
 
 			if boil.IsDebug(ctx) {
 				writer := boil.DebugWriterFrom(ctx)
@@ -959,15 +2050,15 @@ func (o *User) AddComments(ctx context.Context, exec boil.ContextExecutor, inser
 
 	if o.R == nil {
 		o.R = &userR{
-			Comments: related,
+			PostSlugHistories: related,
 		}
 	} else {
-		o.R.Comments = append(o.R.Comments, related...)
+		o.R.PostSlugHistories = append(o.R.PostSlugHistories, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &commentR{
+			rel.R = &postSlugHistoryR{
 				User: o,
 			}
 		} else {
@@ -1083,6 +2174,309 @@ func (o *User) AddPosts(ctx context.Context, exec boil.ContextExecutor, insert b
 	return nil
 }
 
+// AddRecoveryCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.RecoveryCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RecoveryCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"recovery_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, recoveryCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.CodeID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			RecoveryCodes: related,
+		}
+	} else {
+		o.R.RecoveryCodes = append(o.R.RecoveryCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &recoveryCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddReputationEvents adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.ReputationEvents.
+// Sets related.R.User appropriately.
+func (o *User) AddReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"reputation_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.EventID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			ReputationEvents: related,
+		}
+	} else {
+		o.R.ReputationEvents = append(o.R.ReputationEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &reputationEventR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddVoterReputationEvents adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.VoterReputationEvents.
+// Sets related.R.Voter appropriately.
+func (o *User) AddVoterReputationEvents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*ReputationEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.VoterID = o.UserID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"reputation_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"voter_id"}),
+				strmangle.WhereClause("\"", "\"", 2, reputationEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.UserID, rel.EventID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.VoterID = o.UserID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			VoterReputationEvents: related,
+		}
+	} else {
+		o.R.VoterReputationEvents = append(o.R.VoterReputationEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &reputationEventR{
+				Voter: o,
+			}
+		} else {
+			rel.R.Voter = o
+		}
+	}
+	return nil
+}
+
+// AddRoles adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Roles.
+// Sets related.R.Users appropriately.
+func (o *User) AddRoles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Role) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"user_roles\" (\"user_id\", \"role_id\") values ($1, $2)"
+		values := []interface{}{o.UserID, rel.RoleID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &userR{
+			Roles: related,
+		}
+	} else {
+		o.R.Roles = append(o.R.Roles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &roleR{
+				Users: UserSlice{o},
+			}
+		} else {
+			rel.R.Users = append(rel.R.Users, o)
+		}
+	}
+	return nil
+}
+
+// SetRoles removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Users's Roles accordingly.
+// Replaces o.R.Roles with related.
+// Sets related.R.Users's Roles accordingly.
+func (o *User) SetRoles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Role) error {
+	query := "delete from \"user_roles\" where \"user_id\" = $1"
+	values := []interface{}{o.UserID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeRolesFromUsersSlice(o, related)
+	if o.R != nil {
+		o.R.Roles = nil
+	}
+	return o.AddRoles(ctx, exec, insert, related...)
+}
+
+// RemoveRoles relationships from objects passed in.
+// Removes related items from R.Roles (uses pointer comparison, removal does not keep order)
+// Sets related.R.Users.
+func (o *User) RemoveRoles(ctx context.Context, exec boil.ContextExecutor, related ...*Role) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"user_roles\" where \"user_id\" = $1 and \"role_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.UserID}
+	for _, rel := range related {
+		values = append(values, rel.RoleID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeRolesFromUsersSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Roles {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Roles)
+			if ln > 1 && i < ln-1 {
+				o.R.Roles[i] = o.R.Roles[ln-1]
+			}
+			o.R.Roles = o.R.Roles[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeRolesFromUsersSlice(o *User, related []*Role) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Users {
+			if o.UserID != ri.UserID {
+				continue
+			}
+
+			ln := len(rel.R.Users)
+			if ln > 1 && i < ln-1 {
+				rel.R.Users[i] = rel.R.Users[ln-1]
+			}
+			rel.R.Users = rel.R.Users[:ln-1]
+			break
+		}
+	}
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))
@@ -19,3 +19,15 @@ queryParam := "{"+ strings.Join(stringArgs, ",") + "}"
 
 return queryParam
 }
+
+// takes a slice of []string and converts it to a quoted array literal
+// that can be used as an argument in a SQL = ANY() or unnest() query
+func FormatStringSliceForSQLParams(values []string) string {
+	var quotedArgs []string
+	for _, value := range values {
+		escapedValue := strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`)
+		quotedArgs = append(quotedArgs, `"`+escapedValue+`"`)
+	}
+
+	return "{" + strings.Join(quotedArgs, ",") + "}"
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// every token starts with this, so leaked tokens are easy to search for
+const apiTokenPrefix = "cbt_"
+
+// the number of characters of each token stored for display
+const apiTokenDisplayLength = 12
+
+// scopes are stored as strings like "posts:write",
+// since graphQL enum values cannot contain a colon
+var apiTokenScopeNames = map[gql_models.APITokenScope]string{
+	gql_models.APITokenScopePostsWrite:    "posts:write",
+	gql_models.APITokenScopeCommentsWrite: "comments:write",
+}
+
+// APITokenScopeName gets the stored name of a scope
+func APITokenScopeName(scope gql_models.APITokenScope) string {
+	return apiTokenScopeNames[scope]
+}
+
+// HashAPIToken hashes a token for storing in or looking up from api_tokens
+// tokens are random enough that a fast hash is safe
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateAPIToken stores a new token for the user and returns it
+// the full token is only returned here, since only its hash is stored
+func CreateAPIToken(ctx context.Context, exec boil.ContextExecutor, userID int, name string, scopes []gql_models.APITokenScope, expiresIn *time.Duration) (string, *sql_models.APIToken, error) {
+	randomBytes := make([]byte, 32)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	// store each scope once, in a consistent order
+	scopeNames := []string{}
+	for _, scope := range gql_models.AllAPITokenScope {
+		for _, requested := range scopes {
+			if requested == scope {
+				scopeNames = append(scopeNames, APITokenScopeName(scope))
+				break
+			}
+		}
+	}
+
+	apiToken := sql_models.APIToken{
+		UserID:      userID,
+		TokenName:   name,
+		TokenHash:   HashAPIToken(token),
+		TokenPrefix: token[:apiTokenDisplayLength],
+		Scopes:      strings.Join(scopeNames, " "),
+	}
+	if expiresIn != nil {
+		apiToken.ExpiresAt = null.TimeFrom(time.Now().Add(*expiresIn))
+	}
+
+	err = apiToken.Insert(ctx, exec, boil.Infer())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &apiToken, nil
+}
+
+// FindActiveAPIToken gets the unexpired, unrevoked token matching the one sent by a client
+// tokens of deactivated users are not found, so they stop working along with the account
+func FindActiveAPIToken(ctx context.Context, exec boil.ContextExecutor, token string) (*sql_models.APIToken, error) {
+	return sql_models.APITokens(
+		qm.InnerJoin("users u ON u.user_id = api_tokens.user_id"),
+		sql_models.APITokenWhere.TokenHash.EQ(HashAPIToken(token)),
+		sql_models.APITokenWhere.RevokedAt.IsNull(),
+		qm.Where("(api_tokens.expires_at IS NULL OR api_tokens.expires_at > now())"),
+		qm.Where("u.active = true"),
+	).One(ctx, exec)
+}
+
+// TouchAPIToken records when a token was last used, at most once per API_TOKEN_LAST_USED_INTERVAL
+func TouchAPIToken(ctx context.Context, exec boil.ContextExecutor, apiToken *sql_models.APIToken) error {
+	// skip the write when the token was used moments ago, so busy scripts do not update the row on every request
+	if apiToken.LastUsedAt.Valid && time.Since(apiToken.LastUsedAt.Time) <= constants.API_TOKEN_LAST_USED_INTERVAL {
+		return nil
+	}
+
+	apiToken.LastUsedAt = null.TimeFrom(time.Now())
+	_, err := apiToken.Update(ctx, exec, boil.Whitelist(sql_models.APITokenColumns.LastUsedAt))
+	return err
+}
+
+// RevokeUserAPITokens revokes every active token belonging to the user
+func RevokeUserAPITokens(ctx context.Context, exec boil.ContextExecutor, userID int) error {
+	_, err := sql_models.APITokens(
+		sql_models.APITokenWhere.UserID.EQ(userID),
+		sql_models.APITokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, sql_models.M{sql_models.APITokenColumns.RevokedAt: time.Now()})
+	return err
+}
+
+// APITokenScopesFromNames reads the scopes stored on a token
+func APITokenScopesFromNames(scopeNames string) []gql_models.APITokenScope {
+	scopes := []gql_models.APITokenScope{}
+	for _, scope := range gql_models.AllAPITokenScope {
+		for _, name := range strings.Fields(scopeNames) {
+			if name == APITokenScopeName(scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+)
+
+// CommentScoreSQL returns an expression for the vote-based score used by a comment sort
+// scores are computed from the vote counters kept on each comment
+// table is the name or alias of the comments table in the surrounding query
+// sorts by age alone have a constant score
+func CommentScoreSQL(sort gql_models.CommentSort, table string) string {
+	upvotes := table + ".upvotes"
+	downvotes := table + ".downvotes"
+
+	switch sort {
+	case gql_models.CommentSortTop:
+		return "(" + upvotes + " - " + downvotes + ")::DOUBLE PRECISION"
+	case gql_models.CommentSortControversial:
+		return "controversy_score(" + upvotes + ", " + downvotes + ")"
+	case gql_models.CommentSortHot:
+		return "hot_score(" + upvotes + ", " + downvotes + ", " + table + ".created_at)"
+	default:
+		return "0::DOUBLE PRECISION"
+	}
+}
+
+// CommentOrderBy returns the ORDER BY clause for a comment sort
+// created_at and comment_id break ties so pages never skip or repeat comments
+func CommentOrderBy(sort gql_models.CommentSort, table string) string {
+	switch sort {
+	case gql_models.CommentSortOld:
+		return table + ".created_at, " + table + ".comment_id"
+	case gql_models.CommentSortNew:
+		return table + ".created_at DESC, " + table + ".comment_id DESC"
+	default:
+		return CommentScoreSQL(sort, table) + " DESC, " + table + ".created_at DESC, " + table + ".comment_id DESC"
+	}
+}
+
+// CommentAfterCondition returns a where clause matching the comments that come after
+// a (score, created_at, comment_id) position in the given sort
+// the placeholders are passed in so the clause works with both ? and $n params
+func CommentAfterCondition(sort gql_models.CommentSort, table string, scoreParam string, createdAtParam string, idParam string) string {
+	operator := "<"
+	if sort == gql_models.CommentSortOld {
+		operator = ">"
+	}
+	return "(" + CommentScoreSQL(sort, table) + ", " + table + ".created_at, " + table + ".comment_id) " + operator +
+		" (" + scoreParam + "::DOUBLE PRECISION, " + createdAtParam + ", " + idParam + ")"
+}
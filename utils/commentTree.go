@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// a single comment found by the recursive comment tree query
+type commentTreeRow struct {
+	CommentID  int           `boil:"comment_id"`
+	Score      float64       `boil:"score"`
+	CreatedAt  time.Time     `boil:"created_at"`
+	Path       pq.Int64Array `boil:"path"`
+	ChildCount int           `boil:"child_count"`
+}
+
+// FetchCommentTree gets up to maxDepth levels of comments on a post, with at most
+// perLevelLimit replies to each comment, in a single recursive query
+// the cursor continues either the top-level comments or a truncated branch
+func FetchCommentTree(ctx context.Context, exec boil.ContextExecutor, postID int, maxDepth int, perLevelLimit int, sort gql_models.CommentSort, after *string) (*gql_models.CommentTree, error) {
+	parentID, position, err := DecodeCommentTreeCursor(after)
+	if err != nil {
+		return nil, apperrors.WithField(err, "after")
+	}
+
+	// comments stay hidden while their post is in the trash, unpublished, or scheduled
+	postVisible, err := sql_models.Posts(qm.Where("post_id = ? AND "+VisiblePostClause, postID)).Exists(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	if !postVisible {
+		return &gql_models.CommentTree{Nodes: []*gql_models.CommentTreeNode{}}, nil
+	}
+
+	// when continuing a branch, find the path from the top-level comment down to the parent
+	var parentPath pq.Int64Array
+	if parentID != 0 {
+		err = queries.Raw(`WITH RECURSIVE ancestors AS (
+				SELECT comment_id, response_to_comment_id, 0 AS distance FROM comments
+				WHERE comment_id = $1 AND post_id = $2 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.comment_id, c.response_to_comment_id, a.distance + 1 FROM comments c
+				INNER JOIN ancestors a ON c.comment_id = a.response_to_comment_id
+			)
+			SELECT array_agg(comment_id ORDER BY distance DESC) FROM ancestors`, parentID, postID).QueryRowContext(ctx, exec).Scan(&parentPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(parentPath) == 0 {
+			return nil, apperrors.Validation("after", constants.INVALID_CURSOR_ERROR_MESSAGE)
+		}
+	}
+
+	orderBy := CommentOrderBy(sort, "c")
+	score := CommentScoreSQL(sort, "c")
+
+	// the first level is either the top-level comments or the replies to the parent
+	args := []interface{}{postID, perLevelLimit, maxDepth, constants.COMMENT_TREE_MAX_NODES}
+	rootClause := "c.post_id = $1 AND c.response_to_comment_id IS NULL"
+	if parentID != 0 {
+		args = append(args, parentID)
+		rootClause = "c.post_id = $1 AND c.response_to_comment_id = $" + strconv.Itoa(len(args))
+	}
+	if position != nil {
+		args = append(args, position.Score, position.CreatedAt, position.ID)
+		rootClause += " AND " + CommentAfterCondition(sort, "c", "$"+strconv.Itoa(len(args)-2), "$"+strconv.Itoa(len(args)-1), "$"+strconv.Itoa(len(args)))
+	}
+
+	// walk down the thread one level at a time, taking the first perLevelLimit replies
+	// to each comment. sort_path holds each comment's position among its siblings,
+	// so ordering by it lists the tree depth-first in the requested sort
+	var rows []commentTreeRow
+	err = queries.Raw(`WITH RECURSIVE tree AS (
+			SELECT root.comment_id, root.score, root.created_at, 1 AS level, ARRAY[root.comment_id] AS path, ARRAY[root.position] AS sort_path
+			FROM (
+				SELECT c.comment_id, `+score+` AS score, c.created_at, ROW_NUMBER() OVER (ORDER BY `+orderBy+`) AS position
+				FROM comments c
+				WHERE `+rootClause+` AND c.deleted_at IS NULL
+				ORDER BY `+orderBy+`
+				LIMIT $2
+			) root
+			UNION ALL
+			SELECT child.comment_id, child.score, child.created_at, t.level + 1, t.path || child.comment_id, t.sort_path || child.position
+			FROM tree t
+			CROSS JOIN LATERAL (
+				SELECT c.comment_id, `+score+` AS score, c.created_at, ROW_NUMBER() OVER (ORDER BY `+orderBy+`) AS position
+				FROM comments c
+				WHERE c.response_to_comment_id = t.comment_id AND c.deleted_at IS NULL
+				ORDER BY `+orderBy+`
+				LIMIT $2
+			) child
+			WHERE t.level < $3
+		)
+		SELECT tree.comment_id, tree.score, tree.created_at, tree.path,
+			(SELECT COUNT(*) FROM comments r WHERE r.response_to_comment_id = tree.comment_id AND r.deleted_at IS NULL) AS child_count
+		FROM tree
+		ORDER BY tree.sort_path
+		LIMIT $4`, args...).Bind(ctx, exec, &rows)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// get the full comments found in the tree
+	var commentIDs []int
+	for _, row := range rows {
+		commentIDs = append(commentIDs, row.CommentID)
+	}
+	comments, err := sql_models.Comments(qm.Where("comment_id = ANY(?::int[])", FormatSliceForSQLParams(commentIDs))).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	commentsByID := make(map[int]*sql_models.Comment)
+	for _, comment := range comments {
+		commentsByID[comment.CommentID] = comment
+	}
+
+	// count the replies included for each comment and track the last one,
+	// so truncated branches can be continued from where they left off
+	includedReplies := make(map[int]int)
+	lastReply := make(map[int]*KeysetCursor)
+	for _, row := range rows {
+		if _, ok := commentsByID[row.CommentID]; !ok {
+			continue
+		}
+		replyTo := parentID
+		if len(row.Path) > 1 {
+			replyTo = int(row.Path[len(row.Path)-2])
+		}
+		includedReplies[replyTo]++
+		lastReply[replyTo] = &KeysetCursor{Score: row.Score, CreatedAt: row.CreatedAt, ID: row.CommentID}
+	}
+
+	// format the tree for graphQL response, listed depth-first
+	nodes := []*gql_models.CommentTreeNode{}
+	for _, row := range rows {
+		comment, ok := commentsByID[row.CommentID]
+		if !ok {
+			continue
+		}
+
+		path := make([]int, 0, len(parentPath)+len(row.Path))
+		for _, id := range parentPath {
+			path = append(path, int(id))
+		}
+		for _, id := range row.Path {
+			path = append(path, int(id))
+		}
+
+		fmtComment := ConvertComment(comment, row.ChildCount > 0)
+		node := gql_models.CommentTreeNode{
+			Comment:    &fmtComment,
+			Depth:      len(path),
+			Path:       path,
+			ChildCount: row.ChildCount,
+		}
+
+		if row.ChildCount > includedReplies[comment.CommentID] {
+			// a nil position continues from the first reply when none were included
+			repliesCursor := EncodeCommentTreeCursor(comment.CommentID, lastReply[comment.CommentID])
+			node.RepliesCursor = &repliesCursor
+		}
+
+		nodes = append(nodes, &node)
+	}
+
+	tree := gql_models.CommentTree{Nodes: nodes}
+
+	// check for more comments at the first level after the last one included
+	if last, ok := lastReply[parentID]; ok {
+		queryMods := []qm.QueryMod{
+			qm.Where("post_id = ? AND deleted_at IS NULL", postID),
+			qm.Where(CommentAfterCondition(sort, "comments", "?", "?", "?"), last.Score, last.CreatedAt, last.ID),
+		}
+		if parentID == 0 {
+			queryMods = append(queryMods, qm.Where("response_to_comment_id IS NULL"))
+		} else {
+			queryMods = append(queryMods, qm.Where("response_to_comment_id = ?", parentID))
+		}
+
+		more, err := sql_models.Comments(queryMods...).Exists(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+		if more {
+			cursor := EncodeCommentTreeCursor(parentID, last)
+			tree.Cursor = &cursor
+		}
+	}
+
+	return &tree, nil
+}
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// cap the maximum possible page size and return with one extra
+// to check for remaining items
+func connectionLimitPlusOne(first int) int {
+	trueLimit := 20
+	if first > trueLimit {
+		return trueLimit + 1
+	}
+	if first < 0 {
+		return 1
+	}
+	return first + 1
+}
+
+// keysetPage holds the parts of a connection query shared by every model,
+// which is ordered by created_at with the primary key breaking ties between rows created at the same time
+type keysetPage struct {
+	pageMods     []qm.QueryMod
+	limitPlusOne int
+	after        *string
+}
+
+// newKeysetPage decodes the cursor and adds the cursor condition, ordering, and limit to queryMods
+// queryMods are copied, so they can still be used to count every matching row
+func newKeysetPage(queryMods []qm.QueryMod, first int, after *string, idColumn string, newestFirst bool) (*keysetPage, error) {
+	position, err := DecodeKeysetCursor(after)
+	if err != nil {
+		return nil, apperrors.WithField(err, "after")
+	}
+
+	comparison, orderBy := ">", "created_at, "+idColumn
+	if newestFirst {
+		comparison, orderBy = "<", "created_at DESC, "+idColumn+" DESC"
+	}
+
+	page := keysetPage{
+		pageMods:     append([]qm.QueryMod{}, queryMods...),
+		limitPlusOne: connectionLimitPlusOne(first),
+		after:        after,
+	}
+	if position != nil {
+		page.pageMods = append(page.pageMods, qm.Where("(created_at, "+idColumn+") "+comparison+" (?, ?)", position.CreatedAt, position.ID))
+	}
+	page.pageMods = append(page.pageMods, qm.OrderBy(orderBy), qm.Limit(page.limitPlusOne))
+
+	return &page, nil
+}
+
+// size returns how many of the fetched rows belong to the page,
+// dropping the extra row used to check for more
+func (page *keysetPage) size(fetched int) (int, bool) {
+	hasNextPage := fetched == page.limitPlusOne
+	if hasNextPage {
+		return fetched - 1, true
+	}
+	return fetched, false
+}
+
+// pageInfo describes the page from the cursors of its edges
+func (page *keysetPage) pageInfo(cursors []string, hasNextPage bool) *gql_models.PageInfo {
+	pageInfo := gql_models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: page.after != nil,
+	}
+	if len(cursors) > 0 {
+		pageInfo.StartCursor = &cursors[0]
+		pageInfo.EndCursor = &cursors[len(cursors)-1]
+	}
+	return &pageInfo
+}
+
+// PostFilterQueryMods converts the optional title and tag filters into query mods
+func PostFilterQueryMods(filter *gql_models.PostFilter) ([]qm.QueryMod, error) {
+	var queryMods []qm.QueryMod
+	if filter == nil {
+		return queryMods, nil
+	}
+
+	if filter.Title != nil {
+		queryMods = append(queryMods, qm.Where("Title ILIKE ?", "%"+*filter.Title+"%"))
+	}
+
+	if len(filter.Tags) > 0 {
+		tags, err := NormalizeTags(filter.Tags)
+		if err != nil {
+			return nil, apperrors.WithField(err, "filter.tags")
+		}
+		tagParam := FormatStringSliceForSQLParams(tags)
+
+		// match posts with any of the tags, or with every tag when match_all is set
+		if filter.MatchAll != nil && *filter.MatchAll {
+			queryMods = append(queryMods, qm.Where(`post_id IN (
+				SELECT pt.post_id FROM post_tags pt INNER JOIN tags t ON t.tag_id = pt.tag_id
+				WHERE t.tag_name = ANY(?::text[])
+				GROUP BY pt.post_id HAVING COUNT(DISTINCT t.tag_id) = ?)`, tagParam, len(tags)))
+		} else {
+			queryMods = append(queryMods, qm.Where(`post_id IN (
+				SELECT pt.post_id FROM post_tags pt INNER JOIN tags t ON t.tag_id = pt.tag_id
+				WHERE t.tag_name = ANY(?::text[]))`, tagParam))
+		}
+	}
+
+	return queryMods, nil
+}
+
+// FetchPostConnection gets a page of the posts matching queryMods, newest first
+// queryMods should only filter the posts, ordering and limits are added here
+func FetchPostConnection(ctx context.Context, exec boil.ContextExecutor, queryMods []qm.QueryMod, first int, after *string) (*gql_models.PostConnection, error) {
+	page, err := newKeysetPage(queryMods, first, after, "post_id", true)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := sql_models.Posts(queryMods...).Count(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := sql_models.Posts(page.pageMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	size, hasNextPage := page.size(len(posts))
+	posts = posts[:size]
+
+	// format posts for graphQL response
+	edges := make([]*gql_models.PostEdge, len(posts))
+	cursors := make([]string, len(posts))
+	for i, post := range posts {
+		fmtPost := ConvertPost(post)
+		cursors[i] = EncodeKeysetCursor(post.CreatedAt, post.PostID)
+		edges[i] = &gql_models.PostEdge{Cursor: cursors[i], Node: &fmtPost}
+	}
+
+	return &gql_models.PostConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo(cursors, hasNextPage),
+		TotalCount: int(totalCount),
+	}, nil
+}
+
+// FetchUserConnection gets a page of the users matching queryMods, oldest first
+// queryMods should only filter the users, ordering and limits are added here
+func FetchUserConnection(ctx context.Context, exec boil.ContextExecutor, queryMods []qm.QueryMod, first int, after *string) (*gql_models.UserConnection, error) {
+	page, err := newKeysetPage(queryMods, first, after, "user_id", false)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := sql_models.Users(queryMods...).Count(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := sql_models.Users(page.pageMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	size, hasNextPage := page.size(len(users))
+	users = users[:size]
+
+	// format users for graphQL response
+	edges := make([]*gql_models.UserEdge, len(users))
+	cursors := make([]string, len(users))
+	for i, user := range users {
+		fmtUser := ConvertUser(user)
+		cursors[i] = EncodeKeysetCursor(user.CreatedAt, user.UserID)
+		edges[i] = &gql_models.UserEdge{Cursor: cursors[i], Node: &fmtUser}
+	}
+
+	return &gql_models.UserConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo(cursors, hasNextPage),
+		TotalCount: int(totalCount),
+	}, nil
+}
+
+// FetchCommentConnection gets a page of the comments matching queryMods, oldest first
+// queryMods should only filter the comments, ordering and limits are added here
+func FetchCommentConnection(ctx context.Context, exec boil.ContextExecutor, queryMods []qm.QueryMod, first int, after *string) (*gql_models.CommentConnection, error) {
+	page, err := newKeysetPage(queryMods, first, after, "comment_id", false)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := sql_models.Comments(queryMods...).Count(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := sql_models.Comments(page.pageMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	size, hasNextPage := page.size(len(comments))
+	comments = comments[:size]
+
+	// find which comments have subcomments
+	var commentIDs []int
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.CommentID)
+	}
+	subComments, err := sql_models.Comments(qm.Select("response_to_comment_id"), qm.Where("response_to_comment_id = ANY(?::int[]) AND deleted_at IS NULL", FormatSliceForSQLParams(commentIDs))).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	hasSubComments := make(map[int]bool)
+	for _, subComment := range subComments {
+		hasSubComments[subComment.ResponseToCommentID.Int] = true
+	}
+
+	// format comments for graphQL response
+	edges := make([]*gql_models.CommentEdge, len(comments))
+	cursors := make([]string, len(comments))
+	for i, comment := range comments {
+		fmtComment := ConvertComment(comment, hasSubComments[comment.CommentID])
+		cursors[i] = EncodeKeysetCursor(comment.CreatedAt, comment.CommentID)
+		edges[i] = &gql_models.CommentEdge{Cursor: cursors[i], Node: &fmtComment}
+	}
+
+	return &gql_models.CommentConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo(cursors, hasNextPage),
+		TotalCount: int(totalCount),
+	}, nil
+}
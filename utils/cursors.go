@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+)
+
+// invalid cursors are rejected without a field path,
+// since callers name the cursor argument differently
+const offsetCursorPrefix = "offset:"
+
+// EncodeOffsetCursor wraps a result offset in an opaque cursor string
+// used for ranked results, where there is no stable key to page on
+func EncodeOffsetCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.Itoa(offset)))
+}
+
+// DecodeOffsetCursor reads the offset back out of a cursor
+// a nil cursor starts from the first result
+func DecodeOffsetCursor(cursor *string) (int, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), offsetCursorPrefix) {
+		return 0, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), offsetCursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	return offset, nil
+}
+
+const keysetCursorPrefix = "keyset:"
+
+// KeysetCursor is the position of an item in a list ordered by (created_at, id)
+// Score is only used by lists ordered by a vote-based score first
+type KeysetCursor struct {
+	Score     float64
+	CreatedAt time.Time
+	ID        int
+}
+
+// EncodeKeysetCursor wraps the created_at and id of an item in an opaque cursor string
+// created_at is stored in microseconds to match the precision of postgres timestamps
+func EncodeKeysetCursor(createdAt time.Time, id int) string {
+	value := keysetCursorPrefix + strconv.FormatInt(createdAt.UnixMicro(), 10) + ":" + strconv.Itoa(id)
+	return base64.URLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeKeysetCursor reads the position back out of a cursor
+// a nil cursor starts from the first item and returns a nil position
+func DecodeKeysetCursor(cursor *string) (*KeysetCursor, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), keysetCursorPrefix) {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(string(decoded), keysetCursorPrefix), ":")
+	if len(parts) != 2 {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	micros, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	return &KeysetCursor{CreatedAt: time.UnixMicro(micros), ID: id}, nil
+}
+
+const rankCursorPrefix = "rank:"
+
+// EncodeRankCursor wraps the score and id of a ranked item in an opaque cursor string
+func EncodeRankCursor(score float64, id int) string {
+	value := rankCursorPrefix + strconv.FormatFloat(score, 'g', -1, 64) + ":" + strconv.Itoa(id)
+	return base64.URLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeRankCursor reads the score and id back out of a cursor
+// a nil cursor starts from the highest ranked item and returns a nil position
+func DecodeRankCursor(cursor *string) (*KeysetCursor, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), rankCursorPrefix) {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(string(decoded), rankCursorPrefix), ":")
+	if len(parts) != 2 {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	return &KeysetCursor{Score: score, ID: id}, nil
+}
+
+const commentTreeCursorPrefix = "tree:"
+
+// EncodeCommentTreeCursor points at the replies to a comment that come after position
+// a parentID of 0 refers to the top-level comments on the post
+// and a nil position starts from the first reply
+func EncodeCommentTreeCursor(parentID int, position *KeysetCursor) string {
+	value := commentTreeCursorPrefix + strconv.Itoa(parentID)
+	if position != nil {
+		value += ":" + strconv.FormatFloat(position.Score, 'g', -1, 64) +
+			":" + strconv.FormatInt(position.CreatedAt.UnixMicro(), 10) +
+			":" + strconv.Itoa(position.ID)
+	}
+	return base64.URLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeCommentTreeCursor reads the parent comment and position back out of a cursor
+// a nil cursor starts from the first top-level comment
+func DecodeCommentTreeCursor(cursor *string) (int, *KeysetCursor, error) {
+	if cursor == nil {
+		return 0, nil, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), commentTreeCursorPrefix) {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(string(decoded), commentTreeCursorPrefix), ":")
+	if len(parts) != 1 && len(parts) != 4 {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	parentID, err := strconv.Atoi(parts[0])
+	if err != nil || parentID < 0 {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	if len(parts) == 1 {
+		return parentID, nil, nil
+	}
+
+	score, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	micros, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	id, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return 0, nil, apperrors.Validation("", constants.INVALID_CURSOR_ERROR_MESSAGE)
+	}
+
+	return parentID, &KeysetCursor{Score: score, CreatedAt: time.UnixMicro(micros), ID: id}, nil
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+	"github.com/jt-rose/clean_blog_server/mailer"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+)
+
+// redis keys for verification tokens, the latest token sent to each user,
+// and the cooldown between sending them
+const emailVerificationPrefix = "email_verification:"
+const emailVerificationUserPrefix = "email_verification_user:"
+const emailVerificationCooldownPrefix = "email_verification_cooldown:"
+
+// sign a token id with the session key, so tokens can be rejected
+// without a redis lookup when they were not created by this server
+func signVerificationTokenID(tokenID string) string {
+	mac := hmac.New(sha256.New, []byte(constants.ENV_VARIABLES.SESSION_KEY))
+	mac.Write([]byte(emailVerificationPrefix + tokenID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CreateEmailVerificationToken stores a new single-use token for verifying the user's email address
+// and invalidates any token sent to them before
+// returns a rate limited error if a token was created within the resend cooldown
+func CreateEmailVerificationToken(ctx context.Context, userID int, email string) (string, error) {
+	userKey := emailVerificationUserPrefix + strconv.Itoa(userID)
+
+	allowed, err := database.RedisClient.SetNX(ctx, emailVerificationCooldownPrefix+strconv.Itoa(userID), 1, constants.EMAIL_VERIFICATION_RESEND_COOLDOWN).Result()
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return "", apperrors.RateLimited(constants.RATE_LIMITED_ERROR_MESSAGE)
+	}
+
+	randomBytes := make([]byte, 32)
+	_, err = rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+	tokenID := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	// remove the previous token so only the latest link works
+	previousTokenID, err := database.RedisClient.Get(ctx, userKey).Result()
+	if err != nil && err != redis.Nil {
+		return "", err
+	}
+	if previousTokenID != "" {
+		err = database.RedisClient.Del(ctx, emailVerificationPrefix+previousTokenID).Err()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// store the user and the address being verified, so changing the address invalidates the token
+	_, err = database.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, emailVerificationPrefix+tokenID, strconv.Itoa(userID)+":"+email, constants.EMAIL_VERIFICATION_TTL)
+		pipe.Set(ctx, userKey, tokenID, constants.EMAIL_VERIFICATION_TTL)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tokenID + "." + signVerificationTokenID(tokenID), nil
+}
+
+// ConsumeEmailVerificationToken checks the signature of a token and removes it from redis,
+// returning the user and the email address it was sent to
+// each token can only be consumed once
+func ConsumeEmailVerificationToken(ctx context.Context, token string) (int, string, error) {
+	invalidToken := apperrors.Validation("token", constants.INVALID_VERIFICATION_TOKEN_ERROR_MESSAGE)
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(signVerificationTokenID(parts[0]))) {
+		return 0, "", invalidToken
+	}
+
+	// get and delete in one step, so a token cannot be used twice by concurrent requests
+	stored, err := database.RedisClient.GetDel(ctx, emailVerificationPrefix+parts[0]).Result()
+	if err == redis.Nil {
+		return 0, "", invalidToken
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	userAndEmail := strings.SplitN(stored, ":", 2)
+	userID, err := strconv.Atoi(userAndEmail[0])
+	if err != nil || len(userAndEmail) != 2 {
+		return 0, "", invalidToken
+	}
+
+	// the token itself is already used up, so a stale pointer to it is only logged
+	err = database.RedisClient.Del(ctx, emailVerificationUserPrefix+userAndEmail[0]).Err()
+	if err != nil {
+		fmt.Println("Verification token cleanup failed: ", err.Error())
+	}
+	return userID, userAndEmail[1], nil
+}
+
+// SendVerificationEmail creates a new verification token and queues an email
+// with a link for the user to verify their email address
+func SendVerificationEmail(ctx context.Context, user *sql_models.User) error {
+	token, err := CreateEmailVerificationToken(ctx, user.UserID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	msg, err := mailer.NewTemplateMessage(constants.ENV_VARIABLES.EMAIL_ADDRESS, user.Email, "verify_email", map[string]interface{}{
+		"Username":  user.Username,
+		"Link":      fmt.Sprintf("%s/verify-email/%s", constants.ENV_VARIABLES.FRONTEND_URL, token),
+		"ExpiresIn": constants.EMAIL_VERIFICATION_TTL,
+	})
+	if err != nil {
+		return err
+	}
+
+	return mailer.Outbox.Send(ctx, msg)
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+)
+
+// DiffLines compares two strings line by line and returns the lines
+// needed to turn the first into the second, using Myers' O(ND) diff algorithm
+// texts with too many changed lines are rejected rather than compared,
+// since the work grows with the number of lines times the number of edits
+func DiffLines(from string, to string) ([]*gql_models.DiffLine, error) {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+
+	// trim the shared prefix and suffix before searching for edits
+	// since most edits only touch a few lines of a post
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	middleA := a[prefix : len(a)-suffix]
+	middleB := b[prefix : len(b)-suffix]
+
+	if len(middleA)+len(middleB) > constants.REVISION_DIFF_MAX_LINES {
+		return nil, apperrors.Validation("", constants.REVISION_DIFF_TOO_LARGE_ERROR_MESSAGE)
+	}
+
+	middle, ok := myersDiff(middleA, middleB, constants.REVISION_DIFF_MAX_EDITS)
+	if !ok {
+		return nil, apperrors.Validation("", constants.REVISION_DIFF_TOO_LARGE_ERROR_MESSAGE)
+	}
+
+	diff := []*gql_models.DiffLine{}
+	for _, line := range a[:prefix] {
+		diff = append(diff, &gql_models.DiffLine{Operation: gql_models.DiffOperationEqual, Text: line})
+	}
+	diff = append(diff, middle...)
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, &gql_models.DiffLine{Operation: gql_models.DiffOperationEqual, Text: line})
+	}
+
+	return diff, nil
+}
+
+// find the shortest edit script turning a into b, giving up after maxEdits inserted or deleted lines
+// v holds the furthest line of a reached on each diagonal k = x - y,
+// and a copy of v is kept for each number of edits so the path can be walked back
+func myersDiff(a []string, b []string, maxEdits int) ([]*gql_models.DiffLine, bool) {
+	n, m := len(a), len(b)
+	limit := n + m
+	if limit > maxEdits {
+		limit = maxEdits
+	}
+
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		// only diagonals -d to d can have been reached
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				// insert a line of b
+				x = v[offset+k+1]
+			} else {
+				// delete a line of a
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+
+			// follow any matching lines
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrackMyersDiff(a, b, trace), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// walk back from the end of both texts through the stored copies of v
+func backtrackMyersDiff(a []string, b []string, trace [][]int) []*gql_models.DiffLine {
+	x, y := len(a), len(b)
+	var reversed []*gql_models.DiffLine
+
+	for d := len(trace) - 1; d > 0; d-- {
+		// snapshot[k+d] is how far diagonal k had reached after d-1 edits
+		snapshot := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && snapshot[k-1+d] < snapshot[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := snapshot[prevK+d]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, &gql_models.DiffLine{Operation: gql_models.DiffOperationEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, &gql_models.DiffLine{Operation: gql_models.DiffOperationInsert, Text: b[y-1]})
+			y--
+		} else {
+			reversed = append(reversed, &gql_models.DiffLine{Operation: gql_models.DiffOperationDelete, Text: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, &gql_models.DiffLine{Operation: gql_models.DiffOperationEqual, Text: a[x-1]})
+		x--
+		y--
+	}
+
+	diff := make([]*gql_models.DiffLine, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		diff = append(diff, reversed[i])
+	}
+	return diff
+}
@@ -36,9 +36,15 @@ func ConvertPost(sql_post *sql_models.Post) gql_models.Post {
 		UserID: sql_post.UserID,
 		PostID: sql_post.PostID,
 		Title: sql_post.Title,
+		Slug: sql_post.Slug,
 		Subtitle: sql_post.Subtitle,
 		PostText: sql_post.PostText,
+		Format: gql_models.TextFormat(sql_post.Format),
 		CreatedAt: sql_post.CreatedAt,
+		Deleted: sql_post.DeletedAt.Valid,
+		DeletedAt: sql_post.DeletedAt.Ptr(),
+		Published: sql_post.Published,
+		PublishAt: sql_post.PublishAt.Ptr(),
 	}
 }
 
@@ -50,7 +56,10 @@ func ConvertComment(sql_comment *sql_models.Comment, hasSubComments bool) gql_mo
 			PostID: sql_comment.PostID,
 			UserID: sql_comment.UserID,
 			CommentText: sql_comment.CommentText,
+			Format: gql_models.TextFormat(sql_comment.Format),
 			CreatedAt: sql_comment.CreatedAt,
+			Deleted: sql_comment.DeletedAt.Valid,
+			DeletedAt: sql_comment.DeletedAt.Ptr(),
 			HasSubComments: hasSubComments,
 		}
 	} else {
@@ -60,18 +69,26 @@ func ConvertComment(sql_comment *sql_models.Comment, hasSubComments bool) gql_mo
 			PostID: sql_comment.PostID,
 			UserID: sql_comment.UserID,
 			CommentText: sql_comment.CommentText,
+			Format: gql_models.TextFormat(sql_comment.Format),
 			CreatedAt: sql_comment.CreatedAt,
+			Deleted: sql_comment.DeletedAt.Valid,
+			DeletedAt: sql_comment.DeletedAt.Ptr(),
 			HasSubComments: hasSubComments,
 		}
 	}
 }
 
 func ConvertUser(sql_user *sql_models.User) gql_models.User {
+	emailVerified := sql_user.EmailVerifiedAt.Valid
+	twoFactorEnabled := sql_user.TotpEnabledAt.Valid
 	return gql_models.User{
 		UserID: sql_user.UserID,
 		Username: sql_user.Username,
-		Email: sql_user.Email,
+		Email: &sql_user.Email,
+		EmailVerified: &emailVerified,
+		TwoFactorEnabled: &twoFactorEnabled,
 		CreatedAt: sql_user.CreatedAt,
+		Reputation: sql_user.Reputation,
 	}
 }
 
@@ -89,4 +106,28 @@ func ConvertPostVote(sql_post_vote *sql_models.PostVote) gql_models.PostVote {
 		VoteValue: ConvertSQLVoteValueEnums(sql_post_vote.VoteValue),
 		UserID: sql_post_vote.UserID,
 	}
-}
\ No newline at end of file
+}
+func ConvertPostRevision(sql_revision *sql_models.PostRevision) gql_models.PostRevision {
+	return gql_models.PostRevision{
+		RevisionID: sql_revision.RevisionID,
+		PostID: sql_revision.PostID,
+		EditorID: sql_revision.EditorID,
+		Title: sql_revision.Title,
+		Subtitle: sql_revision.Subtitle,
+		PostText: sql_revision.PostText,
+		Format: gql_models.TextFormat(sql_revision.Format),
+		CreatedAt: sql_revision.CreatedAt,
+	}
+}
+
+func ConvertAPIToken(sql_api_token *sql_models.APIToken) gql_models.APIToken {
+	return gql_models.APIToken{
+		TokenID: sql_api_token.TokenID,
+		Name: sql_api_token.TokenName,
+		Prefix: sql_api_token.TokenPrefix,
+		Scopes: APITokenScopesFromNames(sql_api_token.Scopes),
+		ExpiresAt: sql_api_token.ExpiresAt.Ptr(),
+		LastUsedAt: sql_api_token.LastUsedAt.Ptr(),
+		CreatedAt: sql_api_token.CreatedAt,
+	}
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	database "github.com/jt-rose/clean_blog_server/database"
+	"github.com/jt-rose/clean_blog_server/mailer"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+)
+
+// redis keys for reset tokens and the latest token sent to each user
+// tokens are only stored as hashes, so a leaked redis snapshot cannot be used to reset passwords
+const passwordResetPrefix = "password_reset:"
+const passwordResetUserPrefix = "password_reset_user:"
+
+func hashPasswordResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// a short hash of the user's current password hash, stored with each token
+// so changing the password invalidates every token created before
+func passwordStamp(user *sql_models.User) string {
+	hash := sha256.Sum256([]byte(user.UserPassword))
+	return hex.EncodeToString(hash[:8])
+}
+
+// PasswordResetLimitKey names the counter limiting reset requests for an account,
+// shared however the account was identified, by username or email
+// unknown accounts are counted under a hash of the identifier, so the raw input is not stored in redis
+func PasswordResetLimitKey(user *sql_models.User, identifier string) string {
+	if user != nil {
+		return "password_reset:account:" + strconv.Itoa(user.UserID)
+	}
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(identifier))))
+	return "password_reset:unknown:" + hex.EncodeToString(hash[:])
+}
+
+// CreatePasswordResetToken stores a new single-use token for resetting the user's password
+// and invalidates any token sent to them before
+func CreatePasswordResetToken(ctx context.Context, user *sql_models.User) (string, error) {
+	randomBytes := make([]byte, 32)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(randomBytes)
+	tokenHash := hashPasswordResetToken(token)
+
+	err = InvalidatePasswordResetTokens(ctx, user.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	userKey := passwordResetUserPrefix + strconv.Itoa(user.UserID)
+	_, err = database.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, passwordResetPrefix+tokenHash, strconv.Itoa(user.UserID)+":"+passwordStamp(user), constants.PASSWORD_RESET_TTL)
+		pipe.Set(ctx, userKey, tokenHash, constants.PASSWORD_RESET_TTL)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// read the user and password stamp stored with a token
+func parsePasswordResetValue(stored string) (int, string, bool) {
+	userAndStamp := strings.SplitN(stored, ":", 2)
+	if len(userAndStamp) != 2 {
+		return 0, "", false
+	}
+	userID, err := strconv.Atoi(userAndStamp[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return userID, userAndStamp[1], true
+}
+
+// CheckPasswordResetToken confirms a token is active without using it up,
+// so the reset form is only shown for valid links
+func CheckPasswordResetToken(ctx context.Context, token string) error {
+	stored, err := database.RedisClient.Get(ctx, passwordResetPrefix+hashPasswordResetToken(token)).Result()
+	if err == redis.Nil {
+		return apperrors.Validation("resetKey", constants.INVALID_RESET_TOKEN_ERROR_MESSAGE)
+	}
+	if err != nil {
+		return err
+	}
+
+	userID, stamp, ok := parsePasswordResetValue(stored)
+	if !ok {
+		return apperrors.Validation("resetKey", constants.INVALID_RESET_TOKEN_ERROR_MESSAGE)
+	}
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil || passwordStamp(user) != stamp {
+		return apperrors.Validation("resetKey", constants.INVALID_RESET_TOKEN_ERROR_MESSAGE)
+	}
+
+	return nil
+}
+
+// ConsumePasswordResetToken removes a token from redis and returns the user it was sent to
+// the token must belong to userID and be created since the user's last password change
+// each token can only be consumed once, even when it turns out to be invalid
+func ConsumePasswordResetToken(ctx context.Context, token string, userID int) (*sql_models.User, error) {
+	invalidToken := apperrors.Validation("resetKey", constants.INVALID_RESET_TOKEN_ERROR_MESSAGE)
+
+	// get and delete in one step, so a token cannot be used twice by concurrent requests
+	stored, err := database.RedisClient.GetDel(ctx, passwordResetPrefix+hashPasswordResetToken(token)).Result()
+	if err == redis.Nil {
+		return nil, invalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	storedUserID, stamp, ok := parsePasswordResetValue(stored)
+	if !ok || storedUserID != userID {
+		return nil, invalidToken
+	}
+
+	user, err := sql_models.FindUser(ctx, database.DB, userID)
+	if err != nil || passwordStamp(user) != stamp {
+		return nil, invalidToken
+	}
+
+	return user, nil
+}
+
+// InvalidatePasswordResetTokens removes the latest token sent to the user,
+// to be called whenever their password changes
+func InvalidatePasswordResetTokens(ctx context.Context, userID int) error {
+	userKey := passwordResetUserPrefix + strconv.Itoa(userID)
+	tokenHash, err := database.RedisClient.GetDel(ctx, userKey).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return database.RedisClient.Del(ctx, passwordResetPrefix+tokenHash).Err()
+}
+
+// SendPasswordResetEmail creates a new reset token and queues an email
+// with a link for the user to reset their password
+func SendPasswordResetEmail(ctx context.Context, user *sql_models.User) error {
+	token, err := CreatePasswordResetToken(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	msg, err := mailer.NewTemplateMessage(constants.ENV_VARIABLES.EMAIL_ADDRESS, user.Email, "password_reset", map[string]interface{}{
+		"Link":      fmt.Sprintf("%s/reset-password/%d/%s", constants.ENV_VARIABLES.FRONTEND_URL, user.UserID, token),
+		"ExpiresIn": constants.PASSWORD_RESET_TTL,
+	})
+	if err != nil {
+		return err
+	}
+
+	return mailer.Outbox.Send(ctx, msg)
+}
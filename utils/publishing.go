@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	null "github.com/volatiletech/null/v8"
+)
+
+// VisiblePostClauseFor limits a query to posts readers may see, which are published, due, and not in the trash
+// table is the name or alias the posts table is selected under, such as p in a join
+// every visibility check uses this, so a change to what readers may see applies everywhere
+func VisiblePostClauseFor(table string) string {
+	return table + ".published = true AND (" + table + ".publish_at IS NULL OR " + table + ".publish_at <= now()) AND " + table + ".deleted_at IS NULL"
+}
+
+// posts readers may see, for queries on the posts table
+var VisiblePostClause = VisiblePostClauseFor("posts")
+
+// limits a comments query to comments on posts readers may see,
+// so comments stay hidden while their post is in the trash, unpublished, or scheduled
+var VisiblePostCommentsClause = "post_id IN (SELECT posts.post_id FROM posts WHERE " + VisiblePostClause + ")"
+
+// SetPublishStatus applies the published flag and optional publish_at schedule from a PostInput
+// posts scheduled for a future time stay unpublished until the publishing job flips them,
+// so asking to publish a post now while also scheduling it for later is rejected
+func SetPublishStatus(sql_post *sql_models.Post, postInput gql_models.PostInput) error {
+	if postInput.PublishAt == nil {
+		sql_post.Published = postInput.Published
+		sql_post.PublishAt = null.Time{}
+		return nil
+	}
+
+	scheduled := postInput.PublishAt.After(time.Now())
+	if scheduled && postInput.Published {
+		return apperrors.Validation("postInput.published", constants.PUBLISHED_AND_SCHEDULED_ERROR_MESSAGE)
+	}
+
+	sql_post.PublishAt = null.TimeFrom(*postInput.PublishAt)
+	sql_post.Published = !scheduled
+	return nil
+}
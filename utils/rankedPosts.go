@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// posts shown in site-wide listings, limited to active authors
+// expects the author to be joined as u
+func siteWidePostsClause(table string) string {
+	return "u.active = true AND " + VisiblePostClauseFor(table)
+}
+
+// score column in post_rankings for each trending window
+var TrendingScoreColumns = map[gql_models.TrendingWindow]string{
+	gql_models.TrendingWindowDay:   "trending_day",
+	gql_models.TrendingWindowWeek:  "trending_week",
+	gql_models.TrendingWindowMonth: "trending_month",
+}
+
+// score column in post_rankings for each ranked front page sort
+var FrontPageScoreColumns = map[gql_models.PostSort]string{
+	gql_models.PostSortHot: "hot_score",
+	gql_models.PostSortTop: "top_score",
+}
+
+func rankingScore(ranking *sql_models.PostRanking, scoreColumn string) float64 {
+	switch scoreColumn {
+	case "hot_score":
+		return ranking.HotScore
+	case "top_score":
+		return ranking.TopScore
+	case "trending_day":
+		return ranking.TrendingDay.Float64
+	case "trending_week":
+		return ranking.TrendingWeek.Float64
+	default:
+		return ranking.TrendingMonth.Float64
+	}
+}
+
+// FetchRankedPosts gets a page of posts in order of a score column from the post_rankings table
+// scoreColumn must be one of the columns listed in TrendingScoreColumns or FrontPageScoreColumns
+func FetchRankedPosts(ctx context.Context, exec boil.ContextExecutor, scoreColumn string, limitPlusOne int, cursor *string) (*gql_models.RankedPosts, error) {
+	position, err := DecodeRankCursor(cursor)
+	if err != nil {
+		return nil, apperrors.WithField(err, "cursor")
+	}
+
+	// read the next page of rankings, rechecking visibility in case
+	// a post has changed since the rankings were last computed
+	queryMods := []qm.QueryMod{
+		qm.InnerJoin("posts p ON p.post_id = post_rankings.post_id"),
+		qm.InnerJoin("users u ON u.user_id = p.user_id"),
+		qm.Where(siteWidePostsClause("p")),
+		qm.Where("post_rankings." + scoreColumn + " IS NOT NULL"),
+	}
+	if position != nil {
+		queryMods = append(queryMods, qm.Where("(post_rankings."+scoreColumn+", post_rankings.post_id) < (?, ?)", position.Score, position.ID))
+	}
+	queryMods = append(queryMods, qm.OrderBy("post_rankings."+scoreColumn+" DESC, post_rankings.post_id DESC"), qm.Limit(limitPlusOne))
+
+	rankings, err := sql_models.PostRankings(queryMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	// drop the extra ranking used to check for more
+	more := len(rankings) == limitPlusOne
+	if more {
+		rankings = rankings[:len(rankings)-1]
+	}
+
+	// get the posts for the current page
+	var postIDs []int
+	for _, ranking := range rankings {
+		postIDs = append(postIDs, ranking.PostID)
+	}
+	posts, err := sql_models.Posts(qm.Where("post_id = ANY(?::int[])", FormatSliceForSQLParams(postIDs))).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	postsByID := make(map[int]*sql_models.Post)
+	for _, post := range posts {
+		postsByID[post.PostID] = post
+	}
+
+	// format posts for graphQL response in ranked order
+	formattedPosts := []*gql_models.Post{}
+	for _, ranking := range rankings {
+		if post, ok := postsByID[ranking.PostID]; ok {
+			fmtPost := ConvertPost(post)
+			formattedPosts = append(formattedPosts, &fmtPost)
+		}
+	}
+
+	rankedPosts := gql_models.RankedPosts{
+		Posts: formattedPosts,
+		More:  more,
+	}
+	if more && len(formattedPosts) > 0 {
+		last := rankings[len(rankings)-1]
+		nextCursor := EncodeRankCursor(rankingScore(last, scoreColumn), last.PostID)
+		rankedPosts.Cursor = &nextCursor
+	}
+
+	return &rankedPosts, nil
+}
+
+// FetchNewestPosts gets a page of the newest posts across all active authors
+func FetchNewestPosts(ctx context.Context, exec boil.ContextExecutor, limitPlusOne int, cursor *string) (*gql_models.RankedPosts, error) {
+	position, err := DecodeKeysetCursor(cursor)
+	if err != nil {
+		return nil, apperrors.WithField(err, "cursor")
+	}
+
+	queryMods := []qm.QueryMod{
+		qm.InnerJoin("users u ON u.user_id = posts.user_id"),
+		qm.Where(siteWidePostsClause("posts")),
+	}
+	if position != nil {
+		queryMods = append(queryMods, qm.Where("(posts.created_at, posts.post_id) < (?, ?)", position.CreatedAt, position.ID))
+	}
+	queryMods = append(queryMods, qm.OrderBy("posts.created_at DESC, posts.post_id DESC"), qm.Limit(limitPlusOne))
+
+	posts, err := sql_models.Posts(queryMods...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	// drop the extra post used to check for more
+	more := len(posts) == limitPlusOne
+	if more {
+		posts = posts[:len(posts)-1]
+	}
+
+	// format posts for graphQL response
+	formattedPosts := make([]*gql_models.Post, len(posts))
+	for i, post := range posts {
+		fmtPost := ConvertPost(post)
+		formattedPosts[i] = &fmtPost
+	}
+
+	rankedPosts := gql_models.RankedPosts{
+		Posts: formattedPosts,
+		More:  more,
+	}
+	if more && len(formattedPosts) > 0 {
+		last := posts[len(posts)-1]
+		nextCursor := EncodeKeysetCursor(last.CreatedAt, last.PostID)
+		rankedPosts.Cursor = &nextCursor
+	}
+
+	return &rankedPosts, nil
+}
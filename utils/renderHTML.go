@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	"github.com/jt-rose/clean_blog_server/database"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmark_html "github.com/yuin/goldmark/renderer/html"
+)
+
+// bump when the renderer or sanitizer policy changes so stale cached html is ignored
+const renderCacheVersion = "v1"
+
+// github flavored markdown, including tables, strikethrough, autolinks, and task lists
+// raw html is passed through to the sanitizer rather than dropped
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmark_html.WithUnsafe()),
+)
+
+// allowlist of elements and attributes that are safe to render on the frontend
+// scripts, styles, event handlers, and javascript: urls are all stripped
+var htmlSanitizer = newHTMLSanitizer()
+
+func newHTMLSanitizer() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	// keep the language hint on fenced code blocks for syntax highlighting
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// task list items are rendered as disabled checkboxes
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	return policy
+}
+
+// search headlines are built from the raw text, so only the highlight markup is kept
+var headlineSanitizer = bluemonday.NewPolicy().AllowElements("mark")
+
+// SanitizeHeadline strips any markup from a search headline other than <mark> highlights
+func SanitizeHeadline(headline string) string {
+	return headlineSanitizer.Sanitize(headline)
+}
+
+// RenderHTML converts text in the given format into sanitized html
+func RenderHTML(format gql_models.TextFormat, text string) (string, error) {
+	switch format {
+	case gql_models.TextFormatMarkdown:
+		var buf bytes.Buffer
+		err := markdownRenderer.Convert([]byte(text), &buf)
+		if err != nil {
+			return "", err
+		}
+		return htmlSanitizer.Sanitize(buf.String()), nil
+	case gql_models.TextFormatHTML:
+		return htmlSanitizer.Sanitize(text), nil
+	default:
+		return renderPlainText(text), nil
+	}
+}
+
+// plain text is escaped, with blank lines separating paragraphs
+// and single newlines kept as line breaks
+func renderPlainText(text string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		lines := strings.Split(html.EscapeString(paragraph), "\n")
+		paragraphs = append(paragraphs, "<p>"+strings.Join(lines, "<br>\n")+"</p>")
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// RenderCachedHTML renders text as sanitized html, caching the result in redis
+// the cache key is a hash of the format and text, so every revision of a post
+// or comment is cached separately and edits never serve stale output
+func RenderCachedHTML(ctx context.Context, format gql_models.TextFormat, text string) (string, error) {
+	hash := sha256.Sum256([]byte(string(format) + "\x00" + text))
+	cacheKey := "rendered_html:" + renderCacheVersion + ":" + hex.EncodeToString(hash[:])
+
+	cached, err := database.RedisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		return cached, nil
+	}
+
+	rendered, err := RenderHTML(format, text)
+	if err != nil {
+		return "", err
+	}
+
+	// a failed cache write only costs a re-render on the next request
+	database.RedisClient.Set(ctx, cacheKey, rendered, constants.RENDERED_HTML_CACHE_TTL)
+
+	return rendered, nil
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// reputation given for a single vote on a post or comment
+func voteReputation(onComment bool, voteValue int) int {
+	switch {
+	case voteValue == 1 && onComment:
+		return constants.REPUTATION_COMMENT_UPVOTE
+	case voteValue == -1 && onComment:
+		return constants.REPUTATION_COMMENT_DOWNVOTE
+	case voteValue == 1:
+		return constants.REPUTATION_POST_UPVOTE
+	case voteValue == -1:
+		return constants.REPUTATION_POST_DOWNVOTE
+	default:
+		return 0
+	}
+}
+
+// RecordVoteReputation adds a ledger entry for the reputation an author gains or loses
+// when a vote on their post or comment changes from oldValue to newValue,
+// and applies it to the author's reputation total
+// authors do not earn reputation from voting on their own posts and comments
+func RecordVoteReputation(ctx context.Context, exec boil.ContextExecutor, event *sql_models.ReputationEvent, oldValue int, newValue int) error {
+	if event.UserID == event.VoterID {
+		return nil
+	}
+
+	onComment := event.CommentID.Valid
+	event.Points = voteReputation(onComment, newValue) - voteReputation(onComment, oldValue)
+	if event.Points == 0 {
+		return nil
+	}
+
+	event.CreatedAt = time.Now()
+	err := event.Insert(ctx, exec, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	_, err = queries.Raw(`UPDATE users SET reputation = reputation + $1 WHERE user_id = $2`, event.Points, event.UserID).ExecContext(ctx, exec)
+	return err
+}
+
+// starting time for each leaderboard period, the all time leaderboard has none
+var leaderboardPeriodStarts = map[gql_models.LeaderboardPeriod]time.Duration{
+	gql_models.LeaderboardPeriodDay:   time.Hour * 24,
+	gql_models.LeaderboardPeriodWeek:  time.Hour * 24 * 7,
+	gql_models.LeaderboardPeriodMonth: time.Hour * 24 * 30,
+}
+
+// a user's reputation earned within a leaderboard period
+type leaderboardRow struct {
+	UserID     int `boil:"user_id"`
+	Reputation int `boil:"reputation"`
+}
+
+// FetchLeaderboard gets the active users who earned the most reputation within a period
+// the all time leaderboard reads the reputation totals on each user,
+// shorter periods total the ledger entries made within them
+func FetchLeaderboard(ctx context.Context, exec boil.ContextExecutor, period gql_models.LeaderboardPeriod, limit int) ([]*gql_models.LeaderboardEntry, error) {
+	var rows []leaderboardRow
+	var err error
+	if duration, ok := leaderboardPeriodStarts[period]; ok {
+		err = queries.Raw(`SELECT e.user_id, SUM(e.points) AS reputation
+			FROM reputation_events e
+			INNER JOIN users u ON u.user_id = e.user_id
+			WHERE e.created_at > $1 AND u.active = true
+			GROUP BY e.user_id
+			HAVING SUM(e.points) > 0
+			ORDER BY reputation DESC, e.user_id
+			LIMIT $2`, time.Now().Add(-duration), limit).Bind(ctx, exec, &rows)
+	} else {
+		err = queries.Raw(`SELECT user_id, reputation FROM users
+			WHERE active = true AND reputation > 0
+			ORDER BY reputation DESC, user_id
+			LIMIT $1`, limit).Bind(ctx, exec, &rows)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// get the full users on the leaderboard
+	var userIDs []int
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+	users, err := sql_models.Users(qm.Where("user_id = ANY(?::int[])", FormatSliceForSQLParams(userIDs))).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	usersByID := make(map[int]*sql_models.User)
+	for _, user := range users {
+		usersByID[user.UserID] = user
+	}
+
+	// format the leaderboard for graphQL response, keeping the ranked order
+	leaderboard := []*gql_models.LeaderboardEntry{}
+	for _, row := range rows {
+		user, ok := usersByID[row.UserID]
+		if !ok {
+			continue
+		}
+		fmtUser := ConvertUser(user)
+		leaderboard = append(leaderboard, &gql_models.LeaderboardEntry{
+			User:       &fmtUser,
+			Reputation: row.Reputation,
+		})
+	}
+
+	return leaderboard, nil
+}
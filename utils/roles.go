@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// GrantRole gives a user a role by name
+// returns false if the user already had the role
+func GrantRole(ctx context.Context, exec boil.ContextExecutor, userID int, roleName string) (bool, error) {
+	result, err := queries.Raw(`INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, role_id FROM roles WHERE role_name = $2
+		ON CONFLICT DO NOTHING`, userID, roleName).ExecContext(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAff, err := result.RowsAffected()
+	return rowsAff == 1, err
+}
+
+// RevokeRole takes a role away from a user
+// returns false if the user did not have the role
+func RevokeRole(ctx context.Context, exec boil.ContextExecutor, userID int, roleName string) (bool, error) {
+	result, err := queries.Raw(`DELETE FROM user_roles
+		WHERE user_id = $1 AND role_id = (SELECT role_id FROM roles WHERE role_name = $2)`, userID, roleName).ExecContext(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAff, err := result.RowsAffected()
+	return rowsAff == 1, err
+}
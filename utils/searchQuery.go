@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// matches either a "quoted phrase" or a single whitespace-separated token
+var searchTokenPattern = regexp.MustCompile(`"([^"]*)"?|(\S+)`)
+
+// anything other than letters and numbers is stripped so user input
+// can never inject tsquery operators
+var nonWordPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// BuildSearchQuery converts user input into a to_tsquery expression
+// "quoted phrases" must match in order, terms ending in * match as prefixes,
+// and every term must be present in a matching document
+func BuildSearchQuery(query string) string {
+	var terms []string
+	for _, match := range searchTokenPattern.FindAllStringSubmatch(query, -1) {
+		// phrases match adjacent words in order
+		if strings.HasPrefix(match[0], `"`) {
+			words := strings.Fields(strings.ToLower(nonWordPattern.ReplaceAllString(match[1], " ")))
+			if len(words) > 0 {
+				terms = append(terms, "("+strings.Join(words, " <-> ")+")")
+			}
+			continue
+		}
+
+		token := match[2]
+		isPrefix := strings.HasSuffix(token, "*")
+		words := strings.Fields(strings.ToLower(nonWordPattern.ReplaceAllString(token, " ")))
+		for i, word := range words {
+			if isPrefix && i == len(words)-1 {
+				word += ":*"
+			}
+			terms = append(terms, word)
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
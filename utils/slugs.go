@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// slugs are capped well below the column size to keep urls readable
+const maxSlugLength = 80
+
+// Slugify converts a title into a lowercase, hyphen-separated url segment
+// "Hello, World!" becomes "hello-world"
+func Slugify(title string) string {
+	var builder strings.Builder
+	lastWasHyphen := true // prevents a leading hyphen
+	length := 0
+	for _, char := range strings.ToLower(title) {
+		if length >= maxSlugLength {
+			break
+		}
+		if unicode.IsLetter(char) || unicode.IsNumber(char) {
+			builder.WriteRune(char)
+			lastWasHyphen = false
+			length++
+		} else if !lastWasHyphen {
+			builder.WriteRune('-')
+			lastWasHyphen = true
+			length++
+		}
+	}
+
+	slug := strings.Trim(builder.String(), "-")
+	if slug == "" {
+		return "post"
+	}
+	return slug
+}
+
+// the unique constraint on posts (user_id, slug)
+const postSlugConstraint = "posts_user_id_slug_key"
+
+// SavePostWithSlug inserts a new post, or updates an existing one, with a slug generated from its title
+// that is unique for the author. previousTitle is the title before an edit, or "" for a new post,
+// and the current slug is kept when the title change does not affect it.
+// if the post already had a different slug, the old one is added to the slug history
+// so that existing links can be redirected. Must be called within a transaction
+func SavePostWithSlug(ctx context.Context, exec boil.ContextExecutor, sql_post *sql_models.Post, previousTitle string) error {
+	baseSlug := Slugify(sql_post.Title)
+	keepSlug := sql_post.Slug != "" && Slugify(previousTitle) == baseSlug
+
+	// record the old slug so requests for it can be redirected
+	// if another post used this slug in the past, the most recent post takes it over
+	if sql_post.Slug != "" && !keepSlug {
+		history := sql_models.PostSlugHistory{
+			UserID: sql_post.UserID,
+			Slug:   sql_post.Slug,
+			PostID: sql_post.PostID,
+		}
+		err := history.Upsert(ctx, exec, true, []string{"user_id", "slug"}, boil.Whitelist("post_id", "created_at"), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	// try the base slug, then add a numbered suffix each time the unique constraint rejects one,
+	// so titles ending in numbers and posts saved at the same time are both handled by the database
+	for i := 1; ; i++ {
+		if !keepSlug {
+			sql_post.Slug = baseSlug
+			if i > 1 {
+				sql_post.Slug = baseSlug + "-" + strconv.Itoa(i)
+			}
+		}
+
+		// a failed statement aborts the transaction, so each attempt can be undone on its own
+		_, err := exec.ExecContext(ctx, "SAVEPOINT post_slug")
+		if err != nil {
+			return err
+		}
+
+		if sql_post.PostID == 0 {
+			err = sql_post.Insert(ctx, exec, boil.Infer())
+		} else {
+			_, err = sql_post.Update(ctx, exec, boil.Infer())
+		}
+
+		var pqErr *pq.Error
+		if !keepSlug && errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == postSlugConstraint {
+			_, err = exec.ExecContext(ctx, "ROLLBACK TO SAVEPOINT post_slug")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = exec.ExecContext(ctx, "RELEASE SAVEPOINT post_slug")
+		return err
+	}
+}
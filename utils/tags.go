@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// max number of tags per post and max length of each tag in characters, matching tags.tag_name VARCHAR(50)
+const maxTagsPerPost = 10
+const maxTagLength = 50
+
+// NormalizeTags trims, lowercases, and removes duplicate or empty tags
+// so that "Golang" and " golang" map to the same tag
+// errors leave the field path to the caller, such as postInput.tags or filter.tags
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool)
+	normalizedTags := []string{}
+	for _, tag := range tags {
+		normalizedTag := strings.ToLower(strings.TrimSpace(tag))
+		if normalizedTag == "" || seen[normalizedTag] {
+			continue
+		}
+		if utf8.RuneCountInString(normalizedTag) > maxTagLength {
+			return nil, apperrors.Validation("", constants.TAG_TOO_LONG_ERROR_MESSAGE)
+		}
+		seen[normalizedTag] = true
+		normalizedTags = append(normalizedTags, normalizedTag)
+	}
+
+	if len(normalizedTags) > maxTagsPerPost {
+		return nil, apperrors.Validation("", constants.TOO_MANY_TAGS_ERROR_MESSAGE)
+	}
+
+	return normalizedTags, nil
+}
+
+// SetPostTags replaces the tags on a post, creating any tags that do not exist yet
+// should be called within the same transaction that inserts or updates the post
+func SetPostTags(ctx context.Context, exec boil.ContextExecutor, sql_post *sql_models.Post, tags []string) error {
+	normalizedTags, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	// create missing tags, ignoring the ones that already exist
+	_, err = queries.Raw(`INSERT INTO tags (tag_name) SELECT unnest($1::text[]) ON CONFLICT (tag_name) DO NOTHING`, FormatStringSliceForSQLParams(normalizedTags)).ExecContext(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	var tagList []interface{}
+	for _, tag := range normalizedTags {
+		tagList = append(tagList, tag)
+	}
+
+	var sqlTags sql_models.TagSlice
+	if len(tagList) > 0 {
+		sqlTags, err = sql_models.Tags(qm.WhereIn("tag_name IN ?", tagList...)).All(ctx, exec)
+		if err != nil {
+			return err
+		}
+	}
+
+	// replace the existing post_tags rows with the new set
+	return sql_post.SetTags(ctx, exec, false, sqlTags...)
+}
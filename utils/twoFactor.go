@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/constants"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// derive separate keys for encrypting TOTP secrets and hashing recovery codes
+func twoFactorKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(constants.ENV_VARIABLES.TWO_FACTOR_KEY))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// EncryptTOTPSecret encrypts a TOTP secret with AES-GCM for storing in users.totp_secret
+func EncryptTOTPSecret(secret string) (string, error) {
+	block, err := aes.NewCipher(twoFactorKey("totp_secret"))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	// store the nonce in front of the encrypted secret
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptTOTPSecret reverses EncryptTOTPSecret
+func DecryptTOTPSecret(encrypted string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(twoFactorKey("totp_secret"))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted TOTP secret is too short")
+	}
+
+	secret, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// recovery codes are compared without case, spaces, or hyphens,
+// so "ABCDE-FGHIJ" and "abcdefghij" are the same code
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	mac := hmac.New(sha256.New, twoFactorKey("recovery_code"))
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ReplaceRecoveryCodes removes a user's recovery codes and stores new ones,
+// returning the codes so they can be shown to the user once
+func ReplaceRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, userID int) ([]string, error) {
+	_, err := sql_models.RecoveryCodes(sql_models.RecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := []string{}
+	for i := 0; i < constants.TWO_FACTOR_RECOVERY_CODES; i++ {
+		randomBytes := make([]byte, 10)
+		_, err = rand.Read(randomBytes)
+		if err != nil {
+			return nil, err
+		}
+		// 16 base32 characters, shown as four groups of four
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(randomBytes))
+		code := encoded[0:4] + "-" + encoded[4:8] + "-" + encoded[8:12] + "-" + encoded[12:16]
+
+		recoveryCode := sql_models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		}
+		err = recoveryCode.Insert(ctx, exec, boil.Infer())
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// CheckTwoFactorCode confirms a code from the user's authenticator app or one of their unused recovery codes
+// either kind of code is used up, so it cannot be used to sign in again
+func CheckTwoFactorCode(ctx context.Context, exec boil.ContextExecutor, user *sql_models.User, code string) (bool, error) {
+	if !user.TotpEnabledAt.Valid || !user.TotpSecret.Valid {
+		return false, nil
+	}
+
+	secret, err := DecryptTOTPSecret(user.TotpSecret.String)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := ValidateTOTPCode(secret, code, time.Now(), user.TotpLastStep.Int64)
+	if ok {
+		// only the first request to use a step succeeds
+		result, err := queries.Raw(`UPDATE users SET totp_last_step = $1
+			WHERE user_id = $2 AND (totp_last_step IS NULL OR totp_last_step < $1)`, step, user.UserID).ExecContext(ctx, exec)
+		if err != nil {
+			return false, err
+		}
+		updated, err := result.RowsAffected()
+		return updated == 1, err
+	}
+
+	result, err := queries.Raw(`UPDATE recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`, time.Now(), user.UserID, hashRecoveryCode(code)).ExecContext(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+	used, err := result.RowsAffected()
+	return used == 1, err
+}
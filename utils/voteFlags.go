@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jt-rose/clean_blog_server/apperrors"
+	"github.com/jt-rose/clean_blog_server/constants"
+	gql_models "github.com/jt-rose/clean_blog_server/graph/model"
+	sql_models "github.com/jt-rose/clean_blog_server/sql_models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// the tables holding the votes and vote counters for posts or comments
+type voteTables struct {
+	votes    string
+	targets  string
+	idColumn string
+}
+
+var voteTablesByTarget = map[gql_models.ParentType]voteTables{
+	gql_models.ParentTypePost:    {votes: "post_votes", targets: "posts", idColumn: "post_id"},
+	gql_models.ParentTypeComment: {votes: "comment_votes", targets: "comments", idColumn: "comment_id"},
+}
+
+// FlagVote marks a vote as possible manipulation, taking it out of the vote totals
+// and out of the reputation of the post or comment's author
+// returns false when the vote does not exist, is already flagged,
+// or was reinstated by an admin from the same reason and has not been changed since
+func FlagVote(ctx context.Context, exec boil.ContextExecutor, target gql_models.ParentType, targetID int, userID int, reason gql_models.VoteFlagReason) (bool, error) {
+	tables := voteTablesByTarget[target]
+
+	authorID, err := lockVoteTarget(ctx, exec, tables, targetID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var voteValue int
+	err = queries.Raw(`UPDATE `+tables.votes+` SET flagged_at = $1, flag_reason = $2, reinstated_at = NULL, reinstated_reason = NULL
+		WHERE `+tables.idColumn+` = $3 AND user_id = $4 AND flagged_at IS NULL
+		AND (reinstated_reason IS DISTINCT FROM $2 OR updated_at > reinstated_at)
+		RETURNING vote_value`, time.Now(), reason.String(), targetID, userID).QueryRowContext(ctx, exec).Scan(&voteValue)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = ApplyVoteChange(ctx, exec, target, targetID, authorID, userID, voteValue, 0)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReinstateVote clears the flag on a vote after admin review and adds it back
+// into the vote totals and the author's reputation
+// reinstated votes are not flagged again for the same reason unless they are changed,
+// but may still be flagged when they match a different pattern
+func ReinstateVote(ctx context.Context, exec boil.ContextExecutor, target gql_models.ParentType, targetID int, userID int) (*gql_models.Votes, error) {
+	tables := voteTablesByTarget[target]
+
+	authorID, err := lockVoteTarget(ctx, exec, tables, targetID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound(constants.FLAGGED_VOTE_NOT_FOUND_ERROR_MESSAGE)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var voteValue int
+	err = queries.Raw(`UPDATE `+tables.votes+` SET flagged_at = NULL, flag_reason = NULL, reinstated_at = $1, reinstated_reason = flag_reason
+		WHERE `+tables.idColumn+` = $2 AND user_id = $3 AND flagged_at IS NOT NULL
+		RETURNING vote_value`, time.Now(), targetID, userID).QueryRowContext(ctx, exec).Scan(&voteValue)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NotFound(constants.FLAGGED_VOTE_NOT_FOUND_ERROR_MESSAGE)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ApplyVoteChange(ctx, exec, target, targetID, authorID, userID, 0, voteValue)
+}
+
+// lock the post or comment so its counters are changed one vote at a time,
+// and return its author
+func lockVoteTarget(ctx context.Context, exec boil.ContextExecutor, tables voteTables, targetID int) (int, error) {
+	var authorID int
+	err := queries.Raw(`SELECT user_id FROM `+tables.targets+` WHERE `+tables.idColumn+` = $1 FOR UPDATE`, targetID).QueryRowContext(ctx, exec).Scan(&authorID)
+	return authorID, err
+}
+
+// ApplyVoteChange updates the vote counters and the author's reputation when the counted value
+// of a vote changes, and returns the new vote totals
+// the post or comment should already be locked by the surrounding transaction
+func ApplyVoteChange(ctx context.Context, exec boil.ContextExecutor, target gql_models.ParentType, targetID int, authorID int, userID int, oldValue int, newValue int) (*gql_models.Votes, error) {
+	tables := voteTablesByTarget[target]
+
+	upvoteDelta, downvoteDelta := VoteCountDeltas(oldValue, newValue)
+	votes := gql_models.Votes{}
+	err := queries.Raw(`UPDATE `+tables.targets+` SET upvotes = upvotes + $1, downvotes = downvotes + $2
+		WHERE `+tables.idColumn+` = $3 RETURNING upvotes, downvotes`, upvoteDelta, downvoteDelta, targetID).QueryRowContext(ctx, exec).Scan(&votes.Upvote, &votes.Downvote)
+	if err != nil {
+		return nil, err
+	}
+
+	reputationEvent := &sql_models.ReputationEvent{
+		UserID:  authorID,
+		VoterID: userID,
+	}
+	if target == gql_models.ParentTypePost {
+		reputationEvent.PostID = null.IntFrom(targetID)
+	} else {
+		reputationEvent.CommentID = null.IntFrom(targetID)
+	}
+	err = RecordVoteReputation(ctx, exec, reputationEvent, oldValue, newValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &votes, nil
+}
+
+// a flagged vote on either a post or a comment
+type flaggedVoteRow struct {
+	Target     string      `boil:"target"`
+	TargetID   int         `boil:"target_id"`
+	UserID     int         `boil:"user_id"`
+	VoteValue  int         `boil:"vote_value"`
+	FlagReason string      `boil:"flag_reason"`
+	IPAddress  null.String `boil:"ip_address"`
+	UpdatedAt  time.Time   `boil:"updated_at"`
+	FlaggedAt  time.Time   `boil:"flagged_at"`
+}
+
+// FetchFlaggedVotes gets the flagged post and comment votes awaiting admin review,
+// most recently flagged first
+func FetchFlaggedVotes(ctx context.Context, exec boil.ContextExecutor, limitPlusOne int, cursor *string) (*gql_models.FlaggedVotes, error) {
+	offset, err := DecodeOffsetCursor(cursor)
+	if err != nil {
+		return nil, apperrors.WithField(err, "cursor")
+	}
+
+	var rows []flaggedVoteRow
+	err = queries.Raw(`SELECT 'post' AS target, post_id AS target_id, user_id, vote_value, flag_reason, host(ip_address) AS ip_address, updated_at, flagged_at
+		FROM post_votes WHERE flagged_at IS NOT NULL
+		UNION ALL
+		SELECT 'comment', comment_id, user_id, vote_value, flag_reason, host(ip_address), updated_at, flagged_at
+		FROM comment_votes WHERE flagged_at IS NOT NULL
+		ORDER BY flagged_at DESC, target, target_id, user_id
+		LIMIT $1 OFFSET $2`, limitPlusOne, offset).Bind(ctx, exec, &rows)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// check for more votes and trim the extra one
+	more := len(rows) == limitPlusOne
+	if more {
+		rows = rows[:len(rows)-1]
+	}
+
+	// format votes for graphQL response
+	flaggedVotes := []*gql_models.FlaggedVote{}
+	for _, row := range rows {
+		flaggedVotes = append(flaggedVotes, &gql_models.FlaggedVote{
+			Target:    gql_models.ParentType(row.Target),
+			TargetID:  row.TargetID,
+			UserID:    row.UserID,
+			VoteValue: ConvertSQLVoteValueEnums(row.VoteValue),
+			Reason:    gql_models.VoteFlagReason(row.FlagReason),
+			IPAddress: row.IPAddress.Ptr(),
+			VotedAt:   row.UpdatedAt,
+			FlaggedAt: row.FlaggedAt,
+		})
+	}
+
+	result := gql_models.FlaggedVotes{Votes: flaggedVotes, More: more}
+	if more {
+		nextCursor := EncodeOffsetCursor(offset + len(flaggedVotes))
+		result.Cursor = &nextCursor
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+// VoteCountDeltas returns the change to the upvote and downvote counters
+// when a user's vote changes from oldValue to newValue
+// vote values are 1 for an upvote, -1 for a downvote, and 0 for neutral
+func VoteCountDeltas(oldValue int, newValue int) (int, int) {
+	upvoteDelta, downvoteDelta := 0, 0
+
+	switch oldValue {
+	case 1:
+		upvoteDelta--
+	case -1:
+		downvoteDelta--
+	}
+
+	switch newValue {
+	case 1:
+		upvoteDelta++
+	case -1:
+		downvoteDelta++
+	}
+
+	return upvoteDelta, downvoteDelta
+}